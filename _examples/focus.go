@@ -11,34 +11,34 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	editbox.Label(0, 0, 0, 0, 0, "Press TAB to focus input box")
-	editbox.Label(0, 2, 0, 0, 0, "Input 1:")
-	editbox.Label(0, 4, 0, 0, 0, "Input 2:")
-	inputs := [2]*editbox.Editbox{
-		editbox.Input(10, 2, 25, termbox.ColorWhite, termbox.ColorBlue),
-		editbox.Input(10, 4, 25, termbox.ColorWhite, termbox.ColorRed),
-	}
-	termbox.SetCursor(-1, -1)
-	termbox.Flush()
+	editbox.EnableMouse()
+
+	form := editbox.NewForm()
+	form.AddLabel(0, 0, 0, 0, 0, "TAB/Ctrl+O move focus, Enter submits, Esc cancels")
+	form.AddLabel(0, 2, 0, 0, 0, "Input 1:")
+	form.AddEditbox("input1",
+		editbox.Input(10, 2, 25, termbox.ColorWhite, termbox.ColorBlue))
+	form.AddLabel(0, 4, 0, 0, 0, "Input 2:")
+	form.AddEditbox("input2",
+		editbox.Input(10, 4, 25, termbox.ColorWhite, termbox.ColorRed))
+	form.AddLabel(0, 6, 0, 0, 0, "Color:")
+	form.AddSelect("color",
+		editbox.Select(10, 6, 25, 3,
+			termbox.ColorWhite, termbox.ColorBlack,
+			termbox.ColorBlack, termbox.ColorWhite,
+			[]string{"red", "green", "blue"}))
 
-	currentInput := inputs[1]
-	ev := termbox.PollEvent()
-	for {
-		switch ev.Key {
-		case termbox.KeyEsc:
-			termbox.Close()
-			fmt.Println("Input 1: " + inputs[0].Text())
-			fmt.Println("Input 2: " + inputs[1].Text())
-			return
-		case termbox.KeyTab, termbox.KeyEnter:
-			if currentInput == inputs[1] {
-				currentInput = inputs[0]
-			} else {
-				currentInput = inputs[1]
-			}
-			ev = currentInput.WaitExit()
-		default:
-			ev = termbox.PollEvent()
-		}
+	submitted, _, err := form.Run()
+	termbox.Close()
+	if err != nil {
+		panic(err)
+	}
+	if !submitted {
+		fmt.Println("Cancelled")
+		return
 	}
+	values := form.Values()
+	fmt.Println("Input 1: " + values["input1"])
+	fmt.Println("Input 2: " + values["input2"])
+	fmt.Println("Color: " + values["color"])
 }
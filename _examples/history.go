@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	history, err := editbox.NewFileHistory(
+		filepath.Join(os.TempDir(), "editbox_history"), 100)
+	if err != nil {
+		panic(err)
+	}
+	err = termbox.Init()
+	if err != nil {
+		panic(err)
+	}
+	editbox.Label(0, 0, 0, 0, 0, "Up/Down - history, Ctrl+R - search, Enter - save, Esc - exit")
+	editbox.Label(0, 2, 0, 0, 0, "Command:")
+	input := editbox.Input(9, 2, 40, termbox.ColorWhite, termbox.ColorBlue)
+	input.SetHistory(history)
+	for {
+		ev, err := input.WaitExit()
+		if err != nil {
+			termbox.Close()
+			panic(err)
+		}
+		if ev.Key == termbox.KeyEsc {
+			break
+		}
+		if ev.Key == termbox.KeyEnter {
+			input = editbox.Input(9, 2, 40, termbox.ColorWhite, termbox.ColorBlue)
+			input.SetHistory(history)
+		}
+	}
+	termbox.Close()
+	fmt.Printf("History: %v\n", history.Entries())
+}
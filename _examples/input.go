@@ -14,7 +14,10 @@ func main() {
 	editbox.Label(0, 0, 0, 0, 0, "Press Esc, Enter, or Tab to Exit")
 	editbox.Label(0, 1, 0, 0, 0, "Type here:")
 	input := editbox.Input(11, 1, 25, termbox.ColorWhite, termbox.ColorBlue)
-	input.WaitExit()
+	_, err = input.WaitExit()
 	termbox.Close()
+	if err != nil {
+		panic(err)
+	}
 	fmt.Printf("Input was: %s\n", input.Text())
 }
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+)
+
+func main() {
+	err := termbox.Init()
+	if err != nil {
+		panic(err)
+	}
+	editbox.EnableMouse()
+
+	host := editbox.Input(0, 0, 20, termbox.ColorWhite, termbox.ColorBlue)
+	port := editbox.Input(0, 0, 6, termbox.ColorWhite, termbox.ColorBlue)
+	protocol := editbox.Select(0, 0, 10, 2,
+		termbox.ColorWhite, termbox.ColorBlack,
+		termbox.ColorBlack, termbox.ColorWhite,
+		[]string{"http", "https"})
+	notes := editbox.Textarea(0, 0, 20, 3,
+		termbox.ColorWhite, termbox.ColorBlue, true)
+
+	form := editbox.NewForm()
+	form.AddEditbox("host", host)
+	form.AddEditbox("port", port)
+	form.AddSelect("protocol", protocol)
+	form.AddEditbox("notes", notes)
+	form.SetLayout(editbox.Pad(editbox.VStack(
+		editbox.Static(0, 0, "Resize terminal to see layout change"),
+		editbox.Fields().
+			Add("Host:", editbox.Item(host).Fill(true, false)).
+			Add("Port:", editbox.Item(port)).
+			Add("Protocol:", editbox.Item(protocol)).
+			Add("Notes:", editbox.Item(notes).Fill(true, true)).
+			Spacing(1),
+	).Spacing(1), 1, 2, 1, 2))
+
+	submitted, _, err := form.Run()
+	termbox.Close()
+	if err != nil {
+		panic(err)
+	}
+	if submitted {
+		fmt.Println(form.Values())
+	}
+}
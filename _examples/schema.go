@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+)
+
+const schema = `{
+	"fields": [
+		{"name": "host", "label": "Host", "required": true,
+			"default": "localhost"},
+		{"name": "port", "label": "Port", "type": "integer",
+			"default": 8080, "min": 1, "max": 65535, "width": 6},
+		{"name": "tls", "label": "Use TLS", "type": "boolean"},
+		{"name": "mode", "label": "Mode", "type": "select",
+			"options": ["development", "production"]}
+	]
+}`
+
+func main() {
+	s, err := editbox.LoadSchema([]byte(schema))
+	if err != nil {
+		panic(err)
+	}
+	form, err := editbox.NewSchemaForm(s, termbox.ColorWhite, termbox.ColorBlue)
+	if err != nil {
+		panic(err)
+	}
+
+	err = termbox.Init()
+	if err != nil {
+		panic(err)
+	}
+	submitted, _, err := form.Run(func(err error) {
+		w, h := termbox.Size()
+		editbox.Label(0, h-1, w, termbox.ColorRed, 0, err.Error())
+	})
+	termbox.Close()
+	if err != nil {
+		panic(err)
+	}
+	if submitted {
+		json, _ := form.ResultJSON()
+		fmt.Println(string(json))
+	}
+}
@@ -32,7 +32,10 @@ func main() {
 			"plugh",
 			"xyzzy",
 		})
-	input.WaitExit()
+	_, err = input.WaitExit()
 	termbox.Close()
+	if err != nil {
+		panic(err)
+	}
 	fmt.Printf("Input was: %s\n", input.Text())
 }
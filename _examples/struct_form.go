@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+)
+
+type Config struct {
+	Host     string `editbox:"label=Host,width=30"`
+	Port     int    `editbox:"label=Port,width=6"`
+	Protocol string `editbox:"label=Protocol,items=http|https"`
+	Verbose  bool   `editbox:"label=Verbose"`
+	Notes    string `editbox:"label=Notes,widget=textarea"`
+}
+
+func main() {
+	config := Config{Host: "localhost", Port: 8080, Protocol: "http"}
+	form, err := editbox.NewStructForm(&config,
+		termbox.ColorWhite, termbox.ColorBlue)
+	if err != nil {
+		panic(err)
+	}
+
+	err = termbox.Init()
+	if err != nil {
+		panic(err)
+	}
+	editbox.EnableMouse()
+	submitted, _, err := form.Run(func(err error) {
+		w, h := termbox.Size()
+		editbox.Label(0, h-1, w, termbox.ColorRed, 0, err.Error())
+	})
+	termbox.Close()
+	if err != nil {
+		panic(err)
+	}
+	if submitted {
+		fmt.Printf("%+v\n", config)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"github.com/smetana/editbox-go/tcellscreen"
+)
+
+func main() {
+	s, err := tcellscreen.Init()
+	if err != nil {
+		panic(err)
+	}
+	s.EnableMouse()
+
+	fg := termbox.RGBToAttribute(0xee, 0xee, 0xee)
+	bg := termbox.RGBToAttribute(0x30, 0x40, 0x70)
+	form := editbox.NewForm()
+	form.AddLabel(0, 0, 0, fg, 0, "Runs on tcell. Shift+Tab moves focus back.")
+	form.AddEditbox("name", editbox.Input(0, 2, 30, fg, bg))
+	form.AddSelect("color", editbox.Select(0, 4, 30, 3, fg, bg, bg, fg,
+		[]string{"Red", "Green", "Blue"}))
+	form.SetSubmitKeys(termbox.KeyCtrlS)
+
+	submitted, _, err := form.Run()
+	s.Close()
+	if err != nil {
+		panic(err)
+	}
+	if submitted {
+		fmt.Println(form.Values())
+	}
+}
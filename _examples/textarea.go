@@ -38,7 +38,11 @@ func main() {
 			} else {
 				currentInput = inputs[1]
 			}
-			ev = currentInput.WaitExit()
+			ev, err = currentInput.WaitExit()
+			if err != nil {
+				termbox.Close()
+				panic(err)
+			}
 		default:
 			ev = termbox.PollEvent()
 		}
@@ -0,0 +1,345 @@
+package editbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Items of bool fields
+var confirmItems = []string{"Yes", "No"}
+
+// Default widget sizes
+const (
+	defaultFieldWidth     = 30
+	defaultTextareaHeight = 3
+	maxSelectHeight       = 5
+)
+
+// Struct field bound to form widget
+type boundField struct {
+	name   string
+	label  string
+	widget string
+	value  reflect.Value
+	items  []string
+	width  int
+	height int
+}
+
+// Form built from struct fields. Struct fields are configured with tag
+//
+//	`editbox:"label=Host,widget=input,width=30"`
+//
+// Options are:
+//
+//   - label: text of the field label, field name by default
+//   - widget: input, textarea, select or confirm. Strings and numbers
+//     use input by default, bools use confirm
+//   - width, height: widget size
+//   - items: select items separated by |. Select of string field returns
+//     item text, select of int field returns item index
+//
+// Supported field kinds are string, int, uint, float and bool.
+// Unexported fields and fields tagged `editbox:"-"` are skipped.
+type StructForm struct {
+	*Form
+	target reflect.Value
+	fields []*boundField
+}
+
+// Builds form from struct pointed by v. Widgets are pre-filled with
+// current field values and laid out as label-field rows.
+func NewStructForm(v interface{}, fg, bg termbox.Attribute) (*StructForm, error) {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("editbox: pointer to struct expected, got %T", v)
+	}
+	sf := &StructForm{Form: NewForm(), target: ptr.Elem()}
+	layout := Fields()
+	t := sf.target.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, err := parseField(t.Field(i), sf.target.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		if field == nil {
+			continue
+		}
+		sf.fields = append(sf.fields, field)
+		layout.Add(field.label+":", sf.addWidget(field, fg, bg))
+	}
+	sf.SetLayout(Pad(layout.Spacing(1), 1, 2, 1, 2))
+	return sf, nil
+}
+
+// Returns nil if field should be skipped
+func parseField(f reflect.StructField, value reflect.Value) (*boundField, error) {
+	tag := f.Tag.Get("editbox")
+	if f.PkgPath != "" || tag == "-" {
+		return nil, nil
+	}
+	field := &boundField{
+		name:  f.Name,
+		label: f.Name,
+		value: value,
+		width: defaultFieldWidth,
+	}
+	for _, option := range strings.Split(tag, ",") {
+		if option == "" {
+			continue
+		}
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("editbox: field %s: bad tag option %q",
+				f.Name, option)
+		}
+		var err error
+		switch kv[0] {
+		case "label":
+			field.label = kv[1]
+		case "widget":
+			field.widget = kv[1]
+		case "width":
+			field.width, err = strconv.Atoi(kv[1])
+		case "height":
+			field.height, err = strconv.Atoi(kv[1])
+		case "items":
+			field.items = strings.Split(kv[1], "|")
+		default:
+			err = fmt.Errorf("unknown tag option %q", kv[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("editbox: field %s: %v", f.Name, err)
+		}
+	}
+	return field, field.check()
+}
+
+// Checks if widget fits field kind and sets defaults
+func (field *boundField) check() error {
+	kind := field.value.Kind()
+	switch field.widget {
+	case "":
+		field.widget = "input"
+		if kind == reflect.Bool {
+			field.widget = "confirm"
+		} else if field.items != nil {
+			field.widget = "select"
+		}
+		return field.check()
+	case "input":
+		if kind == reflect.Bool {
+			break
+		}
+		if isNumber(kind) || kind == reflect.String {
+			field.height = 1
+			return nil
+		}
+	case "textarea":
+		if kind == reflect.String {
+			if field.height <= 0 {
+				field.height = defaultTextareaHeight
+			}
+			return nil
+		}
+	case "select":
+		if len(field.items) == 0 {
+			return fmt.Errorf("editbox: field %s: select without items",
+				field.name)
+		}
+		if kind == reflect.String || isInt(kind) {
+			field.height = selectHeight(field.height, field.items)
+			return nil
+		}
+	case "confirm":
+		if kind == reflect.Bool {
+			field.items = confirmItems
+			field.height = selectHeight(field.height, field.items)
+			return nil
+		}
+	default:
+		return fmt.Errorf("editbox: field %s: unknown widget %q",
+			field.name, field.widget)
+	}
+	return fmt.Errorf("editbox: field %s: %s widget does not support %s",
+		field.name, field.widget, kind)
+}
+
+// Returns height of Select of items. Non-positive height means
+// all items but not more than maxSelectHeight.
+// Used by StructForm and SchemaForm.
+func selectHeight(height int, items []string) int {
+	if height > 0 {
+		return height
+	}
+	if len(items) > maxSelectHeight {
+		return maxSelectHeight
+	}
+	return len(items)
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || isFloat(kind)
+}
+
+// Creates widget filled with field value and adds it to form.
+// Returns widget layout.
+func (sf *StructForm) addWidget(field *boundField, fg, bg termbox.Attribute) Layout {
+	switch field.widget {
+	case "select", "confirm":
+		sbox := Select(0, 0, field.width, field.height, fg, bg, bg, fg,
+			field.items)
+		sbox.SetSelectedIndex(field.selectedIndex())
+		sf.AddSelect(field.name, sbox)
+		return Item(sbox)
+	case "textarea":
+		ebox := newTextarea(0, 0, field.width, field.height, fg, bg, true)
+		ebox.SetText(field.value.String())
+		sf.AddEditbox(field.name, ebox)
+		return Item(ebox).Fill(true, true)
+	}
+	ebox := newInput(0, 0, field.width, fg, bg)
+	ebox.SetText(fmt.Sprint(field.value.Interface()))
+	sf.AddEditbox(field.name, ebox)
+	return Item(ebox)
+}
+
+// Returns select item matching field value. Int values out of items
+// range select the nearest item.
+func (field *boundField) selectedIndex() int {
+	switch kind := field.value.Kind(); {
+	case kind == reflect.Bool:
+		if field.value.Bool() {
+			return 0
+		}
+		return 1
+	case isInt(kind):
+		i := field.value.Int()
+		if i < 0 {
+			return 0
+		}
+		if i >= int64(len(field.items)) {
+			return len(field.items) - 1
+		}
+		return int(i)
+	}
+	for i, item := range field.items {
+		if item == field.value.String() {
+			return i
+		}
+	}
+	return 0
+}
+
+// Converts widget text into value of field type
+func (field *boundField) parse(text string) (reflect.Value, error) {
+	t := field.value.Type()
+	v := reflect.New(t).Elem()
+	kind := t.Kind()
+	switch {
+	case kind == reflect.Bool:
+		v.SetBool(text == confirmItems[0])
+	case kind == reflect.String:
+		v.SetString(text)
+	case field.widget == "select":
+		for i, item := range field.items {
+			if item == text {
+				v.SetInt(int64(i))
+			}
+		}
+	case isInt(kind):
+		n, err := strconv.ParseInt(strings.TrimSpace(text), 10, t.Bits())
+		if err != nil {
+			return v, fmt.Errorf("%s: invalid integer %q", field.label, text)
+		}
+		v.SetInt(n)
+	case isUint(kind):
+		n, err := strconv.ParseUint(strings.TrimSpace(text), 10, t.Bits())
+		if err != nil {
+			return v, fmt.Errorf("%s: invalid number %q", field.label, text)
+		}
+		v.SetUint(n)
+	case isFloat(kind):
+		n, err := strconv.ParseFloat(strings.TrimSpace(text), t.Bits())
+		if err != nil {
+			return v, fmt.Errorf("%s: invalid number %q", field.label, text)
+		}
+		v.SetFloat(n)
+	}
+	return v, nil
+}
+
+// Parses all widget values. Returns name of the first invalid field.
+func (sf *StructForm) parse() ([]reflect.Value, string, error) {
+	texts := sf.Values()
+	values := make([]reflect.Value, len(sf.fields))
+	for i, field := range sf.fields {
+		v, err := field.parse(texts[field.name])
+		if err != nil {
+			return nil, field.name, err
+		}
+		values[i] = v
+	}
+	return values, "", nil
+}
+
+// Writes widget values into struct fields. Struct is not changed
+// if any value is invalid.
+func (sf *StructForm) Apply() error {
+	values, _, err := sf.parse()
+	if err != nil {
+		return err
+	}
+	for i, field := range sf.fields {
+		field.value.Set(values[i])
+	}
+	return nil
+}
+
+// Runs form until it is cancelled or submitted with valid values.
+// Submitted values are written into struct. Invalid field is focused
+// and error is passed to onError if it is not nil.
+// Returns true if form was submitted and event which finished form.
+// Returns *TerminalError on termbox error event and error of History.Add
+// as is if values cannot be saved to history.
+func (sf *StructForm) Run(onError func(error)) (bool, termbox.Event, error) {
+	return sf.RunContext(context.Background(), onError)
+}
+
+// Same as Run but also returns ctx.Err() when ctx is cancelled
+// or its deadline is exceeded.
+func (sf *StructForm) RunContext(
+	ctx context.Context,
+	onError func(error),
+) (bool, termbox.Event, error) {
+	for {
+		submitted, ev, err := sf.Form.RunContext(ctx)
+		if !submitted || err != nil {
+			return submitted, ev, err
+		}
+		_, name, err := sf.parse()
+		if err == nil {
+			return true, ev, sf.Apply()
+		}
+		sf.Focus(name)
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testConfig struct {
+	Host     string  `editbox:"label=Host name,width=20"`
+	Port     int     `editbox:"label=Port,width=6"`
+	Ratio    float64 `editbox:"width=10"`
+	Protocol string  `editbox:"items=http|https"`
+	Level    int     `editbox:"widget=select,items=low|mid|high"`
+	Verbose  bool
+	Notes    string `editbox:"widget=textarea,height=4"`
+	Ignored  string `editbox:"-"`
+	private  string
+}
+
+func TestStructFormPrefill(t *testing.T) {
+	config := testConfig{
+		Host: "localhost", Port: 8080, Ratio: 0.5, Protocol: "https",
+		Level: 2, Verbose: true, Notes: "foo\nbar",
+	}
+	sf, err := NewStructForm(&config, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, sf.Values(), map[string]string{
+		"Host":     "localhost",
+		"Port":     "8080",
+		"Ratio":    "0.5",
+		"Protocol": "https",
+		"Level":    "high",
+		"Verbose":  "Yes",
+		"Notes":    "foo\nbar",
+	})
+	assert.Equal(t, sf.fields[0].label, "Host name")
+	assert.Equal(t, sf.fields[3].widget, "select")
+	assert.Equal(t, sf.fields[5].widget, "confirm")
+	assert.Equal(t, sf.fields[6].height, 4)
+}
+
+func TestStructFormSelectRange(t *testing.T) {
+	config := testConfig{Level: 7}
+	sf, err := NewStructForm(&config, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, sf.Values()["Level"], "high")
+	config.Level = -1
+	sf, err = NewStructForm(&config, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, sf.Values()["Level"], "low")
+	assert.Nil(t, sf.Apply())
+	assert.Equal(t, config.Level, 0)
+}
+
+func TestStructFormApply(t *testing.T) {
+	config := testConfig{Port: 80, Verbose: true}
+	sf, err := NewStructForm(&config, 0, 0)
+	assert.Nil(t, err)
+	formString(sf.Form, "example.com")
+	formKey(sf.Form, termbox.KeyTab)
+	formKey(sf.Form, termbox.KeyBackspace2)
+	formKey(sf.Form, termbox.KeyBackspace2)
+	formString(sf.Form, "x")
+	assert.Equal(t, sf.Apply().Error(), `Port: invalid integer "x"`)
+	assert.Equal(t, config.Host, "")
+
+	formKey(sf.Form, termbox.KeyBackspace2)
+	formString(sf.Form, "443")
+	formKey(sf.Form, termbox.KeyTab)
+	formString(sf.Form, "1.25")
+	formKey(sf.Form, termbox.KeyTab)
+	formKey(sf.Form, termbox.KeyArrowDown)
+	formKey(sf.Form, termbox.KeyTab)
+	formKey(sf.Form, termbox.KeyArrowDown)
+	formKey(sf.Form, termbox.KeyTab)
+	formKey(sf.Form, termbox.KeyArrowDown)
+	assert.Nil(t, sf.Apply())
+	assert.Equal(t, config, testConfig{
+		Host: "example.com", Port: 443, Ratio: 1.25, Protocol: "https",
+		Level: 1, Verbose: false,
+	})
+}
+
+func TestStructFormErrors(t *testing.T) {
+	_, err := NewStructForm(testConfig{}, 0, 0)
+	assert.Equal(t, err.Error(),
+		"editbox: pointer to struct expected, got editbox.testConfig")
+	_, err = NewStructForm(&struct {
+		A bool `editbox:"widget=textarea"`
+	}{}, 0, 0)
+	assert.Equal(t, err.Error(),
+		"editbox: field A: textarea widget does not support bool")
+	_, err = NewStructForm(&struct {
+		A string `editbox:"widget=select"`
+	}{}, 0, 0)
+	assert.Equal(t, err.Error(), "editbox: field A: select without items")
+	_, err = NewStructForm(&struct {
+		A string `editbox:"width"`
+	}{}, 0, 0)
+	assert.Equal(t, err.Error(), `editbox: field A: bad tag option "width"`)
+}
@@ -0,0 +1,137 @@
+package editbox
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Rectangular selection. Block spans lines between anchor and cursor
+// and columns between anchor and desired cursor column (lastx),
+// so block may be wider than lines it crosses.
+// Works in non-wrap mode only.
+
+func (ed *editor) startBlockSelection() {
+	ed.startSelection()
+	ed.block = true
+}
+
+// Returns block bounds. Right column is exclusive.
+func (ed *editor) blockRange() (top, bottom, left, right int) {
+	top, bottom = ed.anchor.y, ed.cursor.y
+	if top > bottom {
+		top, bottom = bottom, top
+	}
+	left, right = ed.anchor.x, ed.lastx
+	if left > right {
+		left, right = right, left
+	}
+	return
+}
+
+// Returns block columns of line y clipped to line length
+func (ed *editor) blockColumns(y, left, right int) (int, int) {
+	last := ed.lines[y].lastRuneX()
+	if left > last {
+		left = last
+	}
+	if right > last {
+		right = last
+	}
+	return left, right
+}
+
+func (ed *editor) isBlockSelected(x, y int) bool {
+	top, bottom, left, right := ed.blockRange()
+	return y >= top && y <= bottom && x >= left && x < right
+}
+
+func (ed *editor) blockText() string {
+	var b bytes.Buffer
+	top, bottom, left, right := ed.blockRange()
+	for y := top; y <= bottom; y++ {
+		x1, x2 := ed.blockColumns(y, left, right)
+		b.WriteString(string(ed.lines[y].text[x1:x2]))
+		if y < bottom {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
+// Moves block to column x keeping its lines. Block becomes zero width
+// so following inserts go to every line.
+func (ed *editor) collapseBlock(x int) {
+	ed.anchor.x = x
+	ed.cursor.x, _ = ed.blockColumns(ed.cursor.y, x, x)
+	ed.lastx = x
+}
+
+// Deletes block columns from every line. Block stays selected with
+// zero width. Returns false if block has zero width.
+func (ed *editor) deleteBlock() bool {
+	top, bottom, left, right := ed.blockRange()
+	if left == right {
+		return false
+	}
+	for y := top; y <= bottom; y++ {
+		x1, x2 := ed.blockColumns(y, left, right)
+		if x1 < x2 {
+			ed.deleteRange(cursor{x1, y}, cursor{x2, y})
+		}
+	}
+	ed.collapseBlock(left)
+	return true
+}
+
+// Deletes one column before (dx = -1) or at (dx = 0) zero width block
+// from every line.
+func (ed *editor) deleteBlockColumn(dx int) {
+	top, bottom, left, _ := ed.blockRange()
+	x := left + dx
+	if x < 0 {
+		return
+	}
+	for y := top; y <= bottom; y++ {
+		if x < ed.lines[y].lastRuneX() {
+			ed.deleteRange(cursor{x, y}, cursor{x + 1, y})
+		}
+	}
+	ed.collapseBlock(x)
+}
+
+// Inserts s at block left column on every line. Short lines
+// are padded with spaces.
+func (ed *editor) insertBlock(s string) {
+	top, bottom, left, _ := ed.blockRange()
+	cursorY := ed.cursor.y
+	for y := top; y <= bottom; y++ {
+		last := ed.lines[y].lastRuneX()
+		ed.cursor = cursor{last, y}
+		if last < left {
+			ed.setText(strings.Repeat(" ", left-last) + s)
+		} else {
+			ed.cursor.x = left
+			ed.setText(s)
+		}
+	}
+	ed.cursor.y = cursorY
+	ed.collapseBlock(left + len([]rune(s)))
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Starts rectangular selection at cursor position or cancels it
+// if block selection has already started.
+// Typing or pasting single line text replaces block on every line.
+// Does nothing in wrap mode.
+func (ebox *Editbox) ToggleBlockSelection() {
+	ed := ebox.editor
+	if ed.block {
+		ed.clearSelection()
+	} else if !ebox.wrap {
+		ed.clearCarets()
+		ed.startBlockSelection()
+	}
+}
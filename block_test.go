@@ -0,0 +1,89 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+func newBlockTextarea(text string, x1, y1, x2, y2 int) *Editbox {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText(text)
+	eb.SetCursor(x1, y1)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlB)
+	for i := y1; i < y2; i++ {
+		sendKey(eb, termbox.KeyArrowDown)
+	}
+	for i := x1; i < x2; i++ {
+		sendKey(eb, termbox.KeyArrowRight)
+	}
+	return eb
+}
+
+// ----------------------------------------------------------------------------
+
+func TestBlockCopy(t *testing.T) {
+	eb := newBlockTextarea("a1 b1 c1\na2 b2 c2\na3\na4 b4 c4", 3, 0, 5, 1)
+	assert.Equal(t, eb.SelectedText(), "b1\nb2")
+	sendKey(eb, termbox.KeyArrowDown)
+	sendKey(eb, termbox.KeyArrowDown)
+	assert.Equal(t, eb.SelectedText(), "b1\nb2\n\nb4")
+	eb.renderView()
+	assert.Equal(t, eb.highlights[0][:6],
+		[]highlight{hlNone, hlNone, hlNone, hlSelection, hlSelection, hlNone})
+	sendKey(eb, termbox.KeyCtrlC)
+	assert.Equal(t, ClipboardText(), "b1\nb2\n\nb4")
+	assert.Equal(t, eb.SelectedText(), "")
+}
+
+func TestBlockDeleteAndInsert(t *testing.T) {
+	eb := newBlockTextarea("a1 b1 c1\na2\na3 b3 c3", 3, 0, 5, 2)
+	sendKey(eb, termbox.KeyDelete)
+	assert.Equal(t, eb.Text(), "a1  c1\na2\na3  c3")
+	sendString(eb, "xy")
+	assert.Equal(t, eb.Text(), "a1 xy c1\na2 xy\na3 xy c3")
+	assert.Equal(t, eb.editor.cursor, cursor{5, 2})
+	sendKey(eb, termbox.KeyBackspace)
+	assert.Equal(t, eb.Text(), "a1 x c1\na2 x\na3 x c3")
+	sendKey(eb, termbox.KeyCtrlB)
+	sendString(eb, "!")
+	assert.Equal(t, eb.Text(), "a1 x c1\na2 x\na3 x! c3")
+}
+
+func TestBlockPaste(t *testing.T) {
+	eb := newBlockTextarea("123\n456", 1, 0, 2, 1)
+	SetClipboardText("ab")
+	sendKey(eb, termbox.KeyCtrlV)
+	assert.Equal(t, eb.Text(), "1ab3\n4ab6")
+}
+
+func TestBlockNotInWrapMode(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{wrap: true})
+	eb.ToggleBlockSelection()
+	assert.False(t, eb.editor.block)
+	assert.False(t, eb.editor.selecting)
+}
+
+func TestBlockMouseAltDrag(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("a1 b1 c1\na2\na3 b3 c3")
+	eb.renderView()
+	sendMouse(eb, termbox.MouseLeft, termbox.ModAlt, 3, 0)
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 5, 2)
+	sendMouse(eb, termbox.MouseRelease, 0, 5, 2)
+	assert.Equal(t, eb.SelectedText(), "b1\n\nb3")
+	// Block extends past the end of short line
+	sendMouse(eb, termbox.MouseLeft, termbox.ModAlt, 1, 0)
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 4, 1)
+	assert.Equal(t, eb.SelectedText(), "1 b\n2")
+	// Plain drag selects text
+	sendMouse(eb, termbox.MouseRelease, 0, 4, 1)
+	sendMouse(eb, termbox.MouseLeft, 0, 3, 0)
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 1, 1)
+	assert.Equal(t, eb.SelectedText(), "b1 c1\na")
+}
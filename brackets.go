@@ -0,0 +1,78 @@
+package editbox
+
+var defaultBrackets = [][2]rune{{'(', ')'}, {'[', ']'}, {'{', '}'}}
+
+// Returns position of bracket matching the one at x, y.
+// Returns false if there is no bracket at x, y or it is unbalanced.
+func (ed *editor) matchBracket(x, y int, pairs [][2]rune) (cursor, bool) {
+	text := ed.lines[y].text
+	if x >= len(text) {
+		return cursor{}, false
+	}
+	for _, pair := range pairs {
+		switch text[x] {
+		case pair[0]:
+			return ed.scanBracket(x, y, pair[0], pair[1], +1)
+		case pair[1]:
+			return ed.scanBracket(x, y, pair[1], pair[0], -1)
+		}
+	}
+	return cursor{}, false
+}
+
+// Scans text from x, y in dir direction counting nested brackets
+func (ed *editor) scanBracket(x, y int, this, other rune, dir int) (cursor, bool) {
+	depth := 0
+	for y >= 0 && y < len(ed.lines) {
+		text := ed.lines[y].text
+		for ; x >= 0 && x < len(text); x += dir {
+			switch text[x] {
+			case this:
+				depth++
+			case other:
+				depth--
+				if depth == 0 {
+					return cursor{x, y}, true
+				}
+			}
+		}
+		y += dir
+		if y >= 0 && y < len(ed.lines) {
+			if dir > 0 {
+				x = 0
+			} else {
+				x = len(ed.lines[y].text) - 1
+			}
+		}
+	}
+	return cursor{}, false
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Sets bracket pairs which are highlighted when cursor is on one of them.
+// Every pair is a string of two different runes like "()".
+// Call without arguments to disable bracket matching.
+// Textarea matches (), [] and {} by default.
+func (ebox *Editbox) SetBrackets(pairs ...string) {
+	ebox.brackets = nil
+	for _, pair := range pairs {
+		runes := []rune(pair)
+		if len(runes) != 2 || runes[0] == runes[1] {
+			continue
+		}
+		ebox.brackets = append(ebox.brackets, [2]rune{runes[0], runes[1]})
+	}
+}
+
+// Moves cursor to the bracket matching the one under cursor.
+func (ebox *Editbox) JumpToMatchingBracket() {
+	ed := ebox.editor
+	match, ok := ed.matchBracket(ed.cursor.x, ed.cursor.y, ebox.brackets)
+	if ok {
+		ed.cursor = match
+		ed.lastx = match.x
+	}
+}
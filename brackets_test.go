@@ -0,0 +1,51 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatchBracket(t *testing.T) {
+	ed := newEditor()
+	ed.setText("{\n  \"a\": [1, (2)],\n  \"b\": [}\n")
+	match, ok := ed.matchBracket(0, 0, defaultBrackets)
+	assert.True(t, ok)
+	assert.Equal(t, match, cursor{8, 2})
+	match, ok = ed.matchBracket(8, 2, defaultBrackets)
+	assert.True(t, ok)
+	assert.Equal(t, match, cursor{0, 0})
+	match, ok = ed.matchBracket(7, 1, defaultBrackets)
+	assert.True(t, ok)
+	assert.Equal(t, match, cursor{14, 1})
+	match, ok = ed.matchBracket(13, 1, defaultBrackets)
+	assert.True(t, ok)
+	assert.Equal(t, match, cursor{11, 1})
+	// Unbalanced
+	_, ok = ed.matchBracket(7, 2, defaultBrackets)
+	assert.False(t, ok)
+	// Not a bracket
+	_, ok = ed.matchBracket(1, 1, defaultBrackets)
+	assert.False(t, ok)
+	// End of line
+	_, ok = ed.matchBracket(0, 3, defaultBrackets)
+	assert.False(t, ok)
+}
+
+func TestJumpToMatchingBracket(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetBrackets("<>", "''", "()")
+	assert.Equal(t, eb.brackets, [][2]rune{{'<', '>'}, {'(', ')'}})
+	eb.SetText("<a\n(b)>")
+	eb.SetCursor(0, 0)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlRsqBracket)
+	assert.Equal(t, eb.editor.cursor, cursor{3, 1})
+	sendKey(eb, termbox.KeyArrowLeft)
+	sendKey(eb, termbox.KeyCtrlRsqBracket)
+	assert.Equal(t, eb.editor.cursor, cursor{0, 1})
+
+	eb.renderView()
+	assert.Equal(t, eb.highlights[1][:4],
+		[]highlight{hlBracket, hlNone, hlBracket, hlNone})
+}
@@ -0,0 +1,385 @@
+// Command editbox shows editbox widgets from shell scripts.
+// Its options and exit codes are compatible with whiptail:
+//
+//	editbox [--title TITLE] [--stdout] BOX TEXT HEIGHT WIDTH [BOX OPTIONS]
+//
+// Exit code is 0 on OK or Yes, 1 on No and 255 on Esc or error.
+// --yesno and --msgbox show <Yes> <No> and <Ok> buttons: Tab and arrows
+// move between them, Enter presses focused button, Y, N and O keys
+// press buttons directly.
+// Entered text or selected menu tag is printed to stderr
+// or to stdout if --stdout is given.
+package main
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const usage = `Usage: editbox [options] box text height width [box options]
+
+Options:
+  --title TITLE      show title in the top border
+  --stdout           print result to stdout instead of stderr
+
+Boxes:
+  --inputbox    text height width [init]
+  --passwordbox text height width [init]
+  --textarea    text height width [init]
+  --menu        text height width menu-height [tag item]...
+  --yesno       text height width
+  --msgbox      text height width
+
+Zero height or width fits box to its content.
+`
+
+// Exit codes
+const (
+	exitOK     = 0
+	exitNo     = 1
+	exitEsc    = 255
+	exitFailed = 255
+)
+
+// Colors
+const (
+	screenBg = termbox.ColorBlue
+	boxFg    = termbox.ColorBlack
+	boxBg    = termbox.ColorWhite
+	fieldFg  = termbox.ColorWhite
+	fieldBg  = termbox.ColorBlue
+)
+
+type dialog struct {
+	box           string
+	title         string
+	stdout        bool
+	text          string
+	height, width int
+	args          []string
+}
+
+// Box geometry. Widget is placed under text, hint is at the bottom
+type frame struct {
+	x, y, width, height int
+}
+
+func (f frame) innerX() int     { return f.x + 2 }
+func (f frame) innerWidth() int { return f.width - 4 }
+func (f frame) hintY() int      { return f.y + f.height - 2 }
+
+func (f frame) widgetY(widgetHeight int) int {
+	return f.hintY() - widgetHeight
+}
+
+func (f frame) textHeight(widgetHeight int) int {
+	return f.height - 3 - widgetHeight
+}
+
+func parseArgs(args []string) (*dialog, error) {
+	d := &dialog{}
+	for len(args) > 0 && d.box == "" {
+		switch arg := args[0]; arg {
+		case "--title":
+			if len(args) < 2 {
+				return nil, fmt.Errorf("--title requires argument")
+			}
+			d.title = args[1]
+			args = args[2:]
+			continue
+		case "--stdout":
+			d.stdout = true
+		case "--inputbox", "--passwordbox", "--textarea",
+			"--menu", "--yesno", "--msgbox":
+			d.box = arg[2:]
+		default:
+			return nil, fmt.Errorf("unknown option %s", arg)
+		}
+		args = args[1:]
+	}
+	if d.box == "" {
+		return nil, fmt.Errorf("box option required")
+	}
+	if len(args) < 3 {
+		return nil, fmt.Errorf("--%s requires text, height and width", d.box)
+	}
+	var err error
+	d.text = strings.Replace(args[0], `\n`, "\n", -1)
+	if d.height, err = strconv.Atoi(args[1]); err != nil {
+		return nil, fmt.Errorf("invalid height %q", args[1])
+	}
+	if d.width, err = strconv.Atoi(args[2]); err != nil {
+		return nil, fmt.Errorf("invalid width %q", args[2])
+	}
+	d.args = args[3:]
+	if d.box == "menu" {
+		if len(d.args) < 1 {
+			return nil, fmt.Errorf("--menu requires menu-height")
+		}
+		if _, err = strconv.Atoi(d.args[0]); err != nil {
+			return nil, fmt.Errorf("invalid menu-height %q", d.args[0])
+		}
+		if len(d.args) < 3 || len(d.args)%2 != 1 {
+			return nil, fmt.Errorf("--menu requires tag and item pairs")
+		}
+	}
+	return d, nil
+}
+
+// Creates read-only wrapped text area with dialog text
+func (d *dialog) message(x, y, width, height int) *editbox.Editbox {
+	msg := editbox.Textarea(x, y, width, height, boxFg, boxBg, true)
+	msg.SetText(d.text)
+	msg.SetReadOnly(true)
+	msg.SetReadOnlyColors(boxFg, boxBg)
+	msg.Render()
+	return msg
+}
+
+// Returns number of rows of dialog text wrapped to width like message
+// wraps it. Newline takes a cell at the end of its line.
+func (d *dialog) textRows(width int) int {
+	lines := strings.Split(d.text, "\n")
+	rows := 0
+	for i, s := range lines {
+		n := utf8.RuneCountInString(s)
+		if i < len(lines)-1 {
+			n++
+		}
+		if n == 0 {
+			rows++
+		} else {
+			rows += (n-1)/width + 1
+		}
+	}
+	return rows
+}
+
+// Computes box geometry and draws box with dialog text
+func (d *dialog) draw(widgetHeight int) frame {
+	termWidth, termHeight := editbox.GetScreen().Size()
+	f := frame{width: d.width, height: d.height}
+	if f.width <= 0 {
+		f.width = 4
+		for _, s := range strings.Split(d.text, "\n") {
+			if w := len([]rune(s)) + 4; w > f.width {
+				f.width = w
+			}
+		}
+		if f.width < 40 {
+			f.width = 40
+		}
+	}
+	if f.width > termWidth {
+		f.width = termWidth
+	}
+	// Border and at least one column inside
+	if f.width < 5 {
+		f.width = 5
+	}
+	if f.height <= 0 {
+		f.height = d.textRows(f.innerWidth()) + widgetHeight + 3
+	}
+	if f.height > termHeight {
+		f.height = termHeight
+	}
+	if f.height < 3+widgetHeight {
+		f.height = 3 + widgetHeight
+	}
+	f.x = (termWidth - f.width) / 2
+	f.y = (termHeight - f.height) / 2
+
+	editbox.GetScreen().Clear(termbox.ColorDefault, screenBg)
+	drawBorder(f, d.title)
+	if h := f.textHeight(widgetHeight); h > 0 {
+		d.message(f.innerX(), f.y+1, f.innerWidth(), h)
+	}
+	return f
+}
+
+func drawBorder(f frame, title string) {
+	screen := editbox.GetScreen()
+	right, bottom := f.x+f.width-1, f.y+f.height-1
+	editbox.Text(f.x, f.y, f.width, f.height, boxFg, boxBg, "")
+	for x := f.x + 1; x < right; x++ {
+		screen.SetCell(x, f.y, '─', boxFg, boxBg)
+		screen.SetCell(x, bottom, '─', boxFg, boxBg)
+	}
+	for y := f.y + 1; y < bottom; y++ {
+		screen.SetCell(f.x, y, '│', boxFg, boxBg)
+		screen.SetCell(right, y, '│', boxFg, boxBg)
+	}
+	screen.SetCell(f.x, f.y, '┌', boxFg, boxBg)
+	screen.SetCell(right, f.y, '┐', boxFg, boxBg)
+	screen.SetCell(f.x, bottom, '└', boxFg, boxBg)
+	screen.SetCell(right, bottom, '┘', boxFg, boxBg)
+	if title != "" {
+		editbox.Label(f.x+2, f.y, f.width-4, boxFg, boxBg, " "+title+" ")
+	}
+}
+
+func hint(f frame, text string) {
+	editbox.Label(f.innerX(), f.hintY(), f.innerWidth(), boxFg, boxBg, text)
+}
+
+// Runs Input, Password or Textarea until it is submitted or cancelled
+func (d *dialog) edit() (string, int, error) {
+	height := 1
+	if d.box == "textarea" {
+		height = d.height - 5
+		if height < 3 {
+			height = 3
+		}
+	}
+	f := d.draw(height)
+	var ebox *editbox.Editbox
+	submit := termbox.KeyEnter
+	switch d.box {
+	case "inputbox":
+		ebox = editbox.Input(f.innerX(), f.widgetY(height), f.innerWidth(),
+			fieldFg, fieldBg)
+		hint(f, "Enter: OK  Esc: Cancel")
+	case "passwordbox":
+		ebox = editbox.Password(f.innerX(), f.widgetY(height), f.innerWidth(),
+			fieldFg, fieldBg)
+		hint(f, "Enter: OK  Esc: Cancel")
+	case "textarea":
+		ebox = editbox.Textarea(f.innerX(), f.widgetY(height), f.innerWidth(),
+			height, fieldFg, fieldBg, true)
+		submit = termbox.KeyTab
+		hint(f, "Tab: OK  Esc: Cancel")
+	}
+	if len(d.args) > 0 {
+		ebox.SetText(strings.Replace(d.args[0], `\n`, "\n", -1))
+	}
+	for {
+		ev, err := ebox.WaitExit()
+		switch {
+		case err != nil:
+			return "", exitFailed, err
+		case ev.Key == submit:
+			return ebox.Text(), exitOK, nil
+		case ev.Key == termbox.KeyEsc:
+			return "", exitEsc, nil
+		}
+	}
+}
+
+// Runs Select of tag and item pairs. Returns selected tag.
+func (d *dialog) menu() (string, int, error) {
+	height, _ := strconv.Atoi(d.args[0])
+	pairs := d.args[1:]
+	tags := []string{}
+	tagWidth := 0
+	for i := 0; i < len(pairs); i += 2 {
+		tags = append(tags, pairs[i])
+		if w := len([]rune(pairs[i])); w > tagWidth {
+			tagWidth = w
+		}
+	}
+	items := []string{}
+	for i, tag := range tags {
+		items = append(items, fmt.Sprintf("%-*s  %s", tagWidth, tag, pairs[2*i+1]))
+	}
+	if height <= 0 || height > len(items) {
+		height = len(items)
+	}
+	f := d.draw(height)
+	sbox := editbox.Select(f.innerX(), f.widgetY(height), f.innerWidth(), height,
+		boxFg, boxBg, fieldFg, fieldBg, items)
+	hint(f, "Enter: OK  Esc: Cancel")
+	hideCursor()
+	for {
+		ev, err := sbox.WaitExit()
+		switch {
+		case err != nil:
+			return "", exitFailed, err
+		case ev.Key == termbox.KeyEnter:
+			return tags[sbox.SelectedIndex()], exitOK, nil
+		case ev.Key == termbox.KeyEsc:
+			return "", exitEsc, nil
+		}
+	}
+}
+
+// Shows Yes and No buttons like whiptail does
+func (d *dialog) yesno() (string, int, error) {
+	switch yes, ev, err := d.confirm("Yes", "No"); {
+	case err != nil:
+		return "", exitFailed, err
+	case yes:
+		return "", exitOK, nil
+	case ev.Key == termbox.KeyEsc:
+		return "", exitEsc, nil
+	}
+	return "", exitNo, nil
+}
+
+// Shows message with Ok button
+func (d *dialog) msgbox() (string, int, error) {
+	switch yes, _, err := d.confirm("Ok"); {
+	case err != nil:
+		return "", exitFailed, err
+	case yes:
+		return "", exitOK, nil
+	}
+	return "", exitEsc, nil
+}
+
+// Draws box with buttons centered at the bottom and waits until one of
+// them is pressed
+func (d *dialog) confirm(buttons ...string) (bool, termbox.Event, error) {
+	f := d.draw(0)
+	// Confirm separates buttons by two spaces
+	width := -2
+	for _, b := range buttons {
+		width += len([]rune(b)) + 4
+	}
+	x := f.innerX() + (f.innerWidth()-width)/2
+	return editbox.Confirm(x, f.hintY(), boxFg, boxBg, "", buttons...)
+}
+
+func hideCursor() {
+	editbox.GetScreen().SetCursor(-1, -1)
+}
+
+func (d *dialog) run() (string, int, error) {
+	switch d.box {
+	case "menu":
+		return d.menu()
+	case "yesno":
+		return d.yesno()
+	case "msgbox":
+		return d.msgbox()
+	}
+	return d.edit()
+}
+
+func main() {
+	d, err := parseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "editbox: %v\n\n%s", err, usage)
+		os.Exit(exitFailed)
+	}
+	var out io.Writer = os.Stderr
+	if d.stdout {
+		out = os.Stdout
+	}
+	if err := termbox.Init(); err != nil {
+		fmt.Fprintf(os.Stderr, "editbox: %v\n", err)
+		os.Exit(exitFailed)
+	}
+	result, code, err := d.run()
+	termbox.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "editbox: %v\n", err)
+	}
+	fmt.Fprint(out, result)
+	os.Exit(code)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	d, err := parseArgs([]string{"--title", "Setup", "--stdout",
+		"--inputbox", `Host\nname`, "10", "40", "localhost"})
+	assert.Nil(t, err)
+	assert.Equal(t, d, &dialog{
+		box:    "inputbox",
+		title:  "Setup",
+		stdout: true,
+		text:   "Host\nname",
+		height: 10,
+		width:  40,
+		args:   []string{"localhost"},
+	})
+
+	d, err = parseArgs([]string{"--menu", "Mode", "0", "0", "2",
+		"dev", "Development", "prod", "Production"})
+	assert.Nil(t, err)
+	assert.Equal(t, d.box, "menu")
+	assert.Equal(t, d.args, []string{"2", "dev", "Development", "prod",
+		"Production"})
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	for _, c := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{}, "box option required"},
+		{[]string{"--foo"}, "unknown option --foo"},
+		{[]string{"--title"}, "--title requires argument"},
+		{[]string{"--yesno", "Sure?"}, "--yesno requires text, height and width"},
+		{[]string{"--msgbox", "Hi", "x", "0"}, `invalid height "x"`},
+		{[]string{"--msgbox", "Hi", "0", "x"}, `invalid width "x"`},
+		{[]string{"--menu", "Mode", "0", "0"}, "--menu requires menu-height"},
+		{[]string{"--menu", "Mode", "0", "0", "x"}, `invalid menu-height "x"`},
+		{[]string{"--menu", "Mode", "0", "0", "1", "dev"},
+			"--menu requires tag and item pairs"},
+	} {
+		_, err := parseArgs(c.args)
+		assert.Equal(t, err.Error(), c.err)
+	}
+}
+
+func TestTextRows(t *testing.T) {
+	for _, c := range []struct {
+		text string
+		rows int
+	}{
+		{"", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"ab\ncd", 2},
+		{"abcd\n", 3},
+		{"ёжик ёжик", 3},
+	} {
+		d := &dialog{text: c.text}
+		assert.Equal(t, d.textRows(4), c.rows, c.text)
+	}
+}
+
+func useScreen(t *testing.T, s *editbox.MemoryScreen) {
+	old := editbox.GetScreen()
+	t.Cleanup(func() { editbox.SetScreen(old) })
+	editbox.SetScreen(s)
+}
+
+func TestYesNo(t *testing.T) {
+	d := &dialog{box: "yesno", text: "Sure?"}
+	for _, c := range []struct {
+		keys []termbox.Key
+		text string
+		code int
+	}{
+		{[]termbox.Key{termbox.KeyEnter}, "", exitOK},
+		{[]termbox.Key{termbox.KeyTab, termbox.KeyEnter}, "", exitNo},
+		{[]termbox.Key{termbox.KeyArrowRight, termbox.KeyArrowLeft,
+			termbox.KeySpace}, "", exitOK},
+		{[]termbox.Key{termbox.KeyEsc}, "", exitEsc},
+		{nil, "n", exitNo},
+		{nil, "Y", exitOK},
+	} {
+		s := editbox.NewMemoryScreen(40, 5)
+		s.Type(c.text).Press(c.keys...)
+		useScreen(t, s)
+		_, code, err := d.run()
+		assert.Nil(t, err)
+		assert.Equal(t, code, c.code)
+		assert.Equal(t, s.Len(), 0)
+	}
+	s := editbox.GetScreen().(*editbox.MemoryScreen)
+	assert.Equal(t, strings.Split(s.String(), "\n")[2], "│             <Yes>  <No>              │")
+}
+
+func TestMsgbox(t *testing.T) {
+	s := editbox.NewMemoryScreen(40, 5)
+	s.Press(termbox.KeyArrowDown, termbox.KeyEnter)
+	useScreen(t, s)
+	d := &dialog{box: "msgbox", text: "Done"}
+	_, code, err := d.run()
+	assert.Nil(t, err)
+	assert.Equal(t, code, exitOK)
+	assert.Contains(t, s.String(), "<Ok>")
+
+	// Script is over
+	_, code, err = d.run()
+	assert.Equal(t, code, exitFailed)
+	assert.IsType(t, &editbox.TerminalError{}, err)
+}
@@ -0,0 +1,182 @@
+package editbox
+
+import (
+	"unicode/utf8"
+)
+
+// Editbox deals with several kinds of coordinates:
+//
+//   - byte offset in text returned by Text()
+//   - rune offset in text
+//   - logical Position: line and column in runes
+//   - visual row and column in widget content after wrapping
+//   - screen x and y where visual row and column are drawn
+//
+// Functions below convert between them. Out of range values are clamped.
+
+// Returns cursor within text nearest to p
+func (ed *editor) clamp(p Position) cursor {
+	y := p.Line
+	if y > len(ed.lines)-1 {
+		y = len(ed.lines) - 1
+	} else if y < 0 {
+		y = 0
+	}
+	x := p.Col
+	if last := ed.lines[y].lastRuneX(); x > last {
+		x = last
+	} else if x < 0 {
+		x = 0
+	}
+	return cursor{x, y}
+}
+
+func (ed *editor) clampOffset(offset int) int {
+	if offset < 0 {
+		return 0
+	}
+	if length := ed.length(); offset > length {
+		return length
+	}
+	return offset
+}
+
+func (ed *editor) byteOffset(c cursor) int {
+	offset := 0
+	for y := 0; y < c.y; y++ {
+		offset += len(string(ed.lines[y].text))
+	}
+	return offset + len(string(ed.lines[c.y].text[:c.x]))
+}
+
+// Returns position of rune which contains byte at offset
+func (ed *editor) cursorAtByte(offset int) cursor {
+	runeOffset := 0
+	for _, l := range ed.lines {
+		for _, r := range l.text {
+			offset -= utf8.RuneLen(r)
+			if offset < 0 {
+				return ed.cursorAt(runeOffset)
+			}
+			runeOffset++
+		}
+	}
+	return ed.endCursor()
+}
+
+func toPosition(c cursor) Position {
+	return Position{Line: c.y, Col: c.x}
+}
+
+// Returns position of box coordinates x, y.
+// Inverts editorToBox.
+func (ebox *Editbox) boxToEditor(x, y int) cursor {
+	ed := ebox.editor
+	if !ebox.wrap {
+		return ed.clamp(Position{Line: y, Col: x})
+	}
+	line := 0
+	for line < len(ebox.lineBoxY)-1 && ebox.lineBoxY[line+1] <= y {
+		line++
+	}
+	if x < 0 {
+		x = 0
+	} else if x > ebox.width-1 {
+		x = ebox.width - 1
+	}
+	if y < 0 {
+		y = 0
+	}
+	return ed.clamp(Position{
+		Line: line,
+		Col:  (y-ebox.lineBoxY[line])*ebox.width + x,
+	})
+}
+
+// Returns widget with wrapped lines counted for current text. Lines of
+// changed text are counted on copy, so conversions do not change widget
+// state, e.g. height of autoexpanding widget, between renders.
+func (ebox *Editbox) counted() *Editbox {
+	if !ebox.editor.changed {
+		return ebox
+	}
+	c := *ebox
+	ed := *ebox.editor
+	c.editor = &ed
+	c.updateLineOffsets()
+	return &c
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Returns position of rune offset.
+func (ebox *Editbox) OffsetToPosition(offset int) Position {
+	ed := ebox.editor
+	return toPosition(ed.cursorAt(ed.clampOffset(offset)))
+}
+
+// Returns rune offset of position.
+func (ebox *Editbox) PositionToOffset(p Position) int {
+	ed := ebox.editor
+	return ed.offset(ed.clamp(p))
+}
+
+// Returns position of byte offset.
+func (ebox *Editbox) ByteOffsetToPosition(offset int) Position {
+	if offset < 0 {
+		offset = 0
+	}
+	return toPosition(ebox.editor.cursorAtByte(offset))
+}
+
+// Returns byte offset of position.
+func (ebox *Editbox) PositionToByteOffset(p Position) int {
+	ed := ebox.editor
+	return ed.byteOffset(ed.clamp(p))
+}
+
+// Returns visual row and column of position in widget content
+// taking wrapping into account. Scroll is not taken into account.
+func (ebox *Editbox) PositionToVisual(p Position) (row, col int) {
+	counted := ebox.counted()
+	c := counted.editor.clamp(p)
+	col, row = counted.editorToBox(c.x, c.y)
+	return row, col
+}
+
+// Returns position displayed at visual row and column.
+func (ebox *Editbox) VisualToPosition(row, col int) Position {
+	return toPosition(ebox.counted().boxToEditor(col, row))
+}
+
+// Returns screen coordinates of position. Returns false if position
+// is scrolled out of widget.
+func (ebox *Editbox) PositionToScreen(p Position) (x, y int, ok bool) {
+	row, col := ebox.PositionToVisual(p)
+	x = ebox.x + col - ebox.scroll.x
+	y = ebox.y + row - ebox.scroll.y
+	ok = x >= ebox.x && x < ebox.x+ebox.width &&
+		y >= ebox.y && y < ebox.y+ebox.height
+	return x, y, ok
+}
+
+// Returns position displayed at screen coordinates x, y or the nearest
+// one. Returns false if x, y is outside of widget.
+func (ebox *Editbox) ScreenToPosition(x, y int) (Position, bool) {
+	ok := x >= ebox.x && x < ebox.x+ebox.width &&
+		y >= ebox.y && y < ebox.y+ebox.height
+	p := ebox.VisualToPosition(y-ebox.y+ebox.scroll.y, x-ebox.x+ebox.scroll.x)
+	return p, ok
+}
+
+// Returns cursor line and column in widget content.
+func (ebox *Editbox) LogicalCursor() Position {
+	return ebox.editor.position()
+}
+
+// Returns cursor row and column in widget content after wrapping.
+func (ebox *Editbox) VisualCursor() (row, col int) {
+	return ebox.PositionToVisual(ebox.editor.position())
+}
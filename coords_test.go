@@ -0,0 +1,75 @@
+package editbox
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOffsetConversion(t *testing.T) {
+	eb := newEditbox(0, 0, 3, 3, options{})
+	eb.SetText("aж\n€b")
+	assert.Equal(t, eb.OffsetToPosition(0), Position{0, 0})
+	assert.Equal(t, eb.OffsetToPosition(2), Position{0, 2})
+	assert.Equal(t, eb.OffsetToPosition(3), Position{1, 0})
+	assert.Equal(t, eb.OffsetToPosition(100), Position{1, 2})
+	assert.Equal(t, eb.OffsetToPosition(-1), Position{0, 0})
+	assert.Equal(t, eb.PositionToOffset(Position{1, 1}), 4)
+	assert.Equal(t, eb.PositionToOffset(Position{0, 100}), 2)
+
+	// ж is 2 bytes, € is 3 bytes
+	assert.Equal(t, eb.PositionToByteOffset(Position{0, 2}), 3)
+	assert.Equal(t, eb.PositionToByteOffset(Position{1, 1}), 7)
+	assert.Equal(t, eb.ByteOffsetToPosition(2), Position{0, 1})
+	assert.Equal(t, eb.ByteOffsetToPosition(3), Position{0, 2})
+	assert.Equal(t, eb.ByteOffsetToPosition(4), Position{1, 0})
+	assert.Equal(t, eb.ByteOffsetToPosition(7), Position{1, 1})
+	assert.Equal(t, eb.ByteOffsetToPosition(100), Position{1, 2})
+}
+
+func TestVisualConversion(t *testing.T) {
+	eb := newEditbox(2, 1, 3, 3, options{wrap: true})
+	eb.SetText("1234567\n12\n1234\n1")
+	row, col := eb.PositionToVisual(Position{0, 4})
+	assert.Equal(t, row, 1)
+	assert.Equal(t, col, 1)
+	row, col = eb.PositionToVisual(Position{2, 3})
+	assert.Equal(t, row, 5)
+	assert.Equal(t, col, 0)
+	assert.Equal(t, eb.VisualToPosition(1, 1), Position{0, 4})
+	assert.Equal(t, eb.VisualToPosition(5, 0), Position{2, 3})
+	assert.Equal(t, eb.VisualToPosition(3, 2), Position{1, 2})
+	// Past line end
+	assert.Equal(t, eb.VisualToPosition(5, 2), Position{2, 4})
+	assert.Equal(t, eb.VisualToPosition(100, 100), Position{3, 1})
+
+	eb.SetCursor(1, 3)
+	row, col = eb.VisualCursor()
+	assert.Equal(t, row, 6)
+	assert.Equal(t, col, 1)
+	assert.Equal(t, eb.LogicalCursor(), Position{3, 1})
+
+	eb.renderView()
+	x, y, ok := eb.PositionToScreen(Position{3, 0})
+	assert.True(t, ok)
+	assert.Equal(t, x, 2)
+	assert.Equal(t, y, 1+6-eb.scroll.y)
+	_, _, ok = eb.PositionToScreen(Position{0, 0})
+	assert.False(t, ok)
+	p, ok := eb.ScreenToPosition(3, 3)
+	assert.True(t, ok)
+	assert.Equal(t, p, Position{3, 1})
+	_, ok = eb.ScreenToPosition(0, 0)
+	assert.False(t, ok)
+}
+
+func TestVisualConversionKeepsState(t *testing.T) {
+	eb := newEditbox(0, 0, 3, 1, options{wrap: true, autoexpand: true,
+		maxHeight: 5})
+	eb.SetText("1234567")
+	row, col := eb.PositionToVisual(Position{0, 7})
+	assert.Equal(t, row, 2)
+	assert.Equal(t, col, 1)
+	assert.Equal(t, eb.VisualToPosition(1, 0), Position{0, 3})
+	assert.Equal(t, eb.height, 1)
+	assert.Nil(t, eb.lineBoxY)
+}
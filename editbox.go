@@ -2,20 +2,44 @@ package editbox
 
 import (
 	"bufio"
+	"context"
 	"github.com/nsf/termbox-go"
 	"strings"
+	"time"
+	"unicode"
 )
 
-func check(e error) {
-	if e != nil {
-		panic(e)
-	}
-}
-
 type cursor struct {
 	x, y int
 }
 
+// Cell highlighting in rendered view
+type highlight uint8
+
+const (
+	hlNone highlight = iota
+	hlSelection
+	hlBracket
+	hlCursor
+)
+
+// Position in Editbox content. Line and Col are zero based,
+// Col is counted in runes.
+type Position struct {
+	Line, Col int
+}
+
+// Describes single modification of Editbox content.
+// Inserted text occupies Start..End after the change,
+// deleted text occupied Start..End before the change.
+// If both are set Deleted text starting at Start was replaced
+// with Inserted text which ends at End.
+type Change struct {
+	Start, End Position
+	Inserted   string
+	Deleted    string
+}
+
 type options struct {
 	fg         termbox.Attribute
 	bg         termbox.Attribute
@@ -39,11 +63,30 @@ type Editbox struct {
 	printNL       bool
 	exitKeys      []termbox.Key
 	view          [][]rune
+	highlights    [][]highlight
+	brackets      [][2]rune
 	// Line y coord in box in wrap mode
 	lineBoxY      []int
 	virtualHeight int
 	minHeight     int
 	maxHeight     int
+	history       *historyBrowser
+	onCursorMove  func(Position)
+	readOnly      bool
+	rofg, robg    termbox.Attribute
+	reflowWidth   int
+	mouse         mouseState
+	// View is scrolled by mouse wheel and does not follow cursor
+	freeScroll bool
+	onResize   ResizeFunc
+	// Rune displayed instead of text runes
+	mask rune
+	// Blurred widget does not show cursor
+	blurred bool
+	// nil means shared clipboard
+	clipboard Clipboard
+	// Times double clicks, time.Now if nil. Replaced in tests
+	clock func() time.Time
 }
 
 func newEditbox(x, y, width, height int, options options) *Editbox {
@@ -54,6 +97,8 @@ func newEditbox(x, y, width, height int, options options) *Editbox {
 	ebox.height = height
 	ebox.fg = options.fg
 	ebox.bg = options.bg
+	ebox.rofg = options.fg | termbox.AttrDim
+	ebox.robg = options.bg
 	ebox.wrap = options.wrap
 	ebox.autoexpand = options.autoexpand
 	if ebox.autoexpand {
@@ -102,6 +147,18 @@ func (ebox *Editbox) updateLineOffsets() {
 	}
 	// else Ok. don't change height
 	ebox.cursor.x, ebox.cursor.y = ebox.editorToBox(ed.cursor.x, ed.cursor.y)
+	ed.changed = false
+}
+
+// Recounts wrapped lines if text changed, otherwise only places
+// box cursor. Unlike updateLineOffsets it is cheap for cursor movement.
+func (ebox *Editbox) syncLines() {
+	if ebox.editor.changed {
+		ebox.updateLineOffsets()
+		return
+	}
+	ed := ebox.editor
+	ebox.cursor.x, ebox.cursor.y = ebox.editorToBox(ed.cursor.x, ed.cursor.y)
 }
 
 func (ebox *Editbox) editorToBox(x, y int) (int, int) {
@@ -221,16 +278,21 @@ func (ebox *Editbox) scrollToCursor() {
 
 func (ebox *Editbox) renderView() {
 	ebox.updateLineOffsets()
-	ebox.scrollToCursor()
+	if !ebox.freeScroll {
+		ebox.scrollToCursor()
+	}
 	ed := ebox.editor
 	var (
 		boxX, boxY   int
 		viewX, viewY int
 	)
 	ebox.view = make([][]rune, ebox.height)
+	ebox.highlights = make([][]highlight, ebox.height)
 	for i := range ebox.view {
 		ebox.view[i] = make([]rune, ebox.width)
+		ebox.highlights[i] = make([]highlight, ebox.width)
 	}
+	bracket, matched := ed.matchBracket(ed.cursor.x, ed.cursor.y, ebox.brackets)
 	for y, line := range ed.lines {
 		for x, r := range line.text {
 			boxX, boxY = ebox.editorToBox(x, y)
@@ -252,22 +314,105 @@ func (ebox *Editbox) renderView() {
 				} else {
 					r = ' '
 				}
+			} else if ebox.mask != 0 {
+				r = ebox.mask
 			}
 			ebox.view[viewY][viewX] = r
+			switch {
+			case ed.isSelected(x, y):
+				ebox.highlights[viewY][viewX] = hlSelection
+			case matched && (bracket == cursor{x, y} || ed.cursor == cursor{x, y}):
+				ebox.highlights[viewY][viewX] = hlBracket
+			}
 		}
 		if viewY > ebox.height-1 {
 			break
 		}
 	}
+	// Terminal has only one cursor so additional ones are highlighted
+	for _, c := range ed.carets {
+		boxX, boxY = ebox.editorToBox(c.cursor.x, c.cursor.y)
+		viewX = boxX - ebox.scroll.x
+		viewY = boxY - ebox.scroll.y
+		if viewX >= 0 && viewX < ebox.width && viewY >= 0 && viewY < ebox.height {
+			ebox.highlights[viewY][viewX] = hlCursor
+		}
+	}
+}
+
+// Replaces selection with rune. Does nothing in read-only mode.
+func (ebox *Editbox) insertRune(r rune) {
+	if ebox.readOnly {
+		return
+	}
+	ed := ebox.editor
+	if ed.block && r != '\n' {
+		ed.deleteBlock()
+		ed.insertBlock(string(r))
+		return
+	}
+	ed.deleteSelection()
+	ed.clearSelection()
+	ed.insertRune(r)
+}
+
+func (ebox *Editbox) insertRuneAtCursors(r rune) {
+	ebox.editor.forEachCursor(func() {
+		ebox.insertRune(r)
+	})
+}
+
+// Deletes selection or rune before cursor.
+// Does nothing in read-only mode.
+func (ebox *Editbox) deleteRuneBeforeCursor() {
+	if ebox.readOnly {
+		return
+	}
+	ed := ebox.editor
+	if ed.deleteSelection() {
+		return
+	}
+	if ed.block {
+		ed.deleteBlockColumn(-1)
+	} else {
+		ed.deleteRuneBeforeCursor()
+	}
+}
+
+// Deletes selection or rune at cursor.
+// Does nothing in read-only mode.
+func (ebox *Editbox) deleteRuneAtCursor() {
+	if ebox.readOnly {
+		return
+	}
+	ed := ebox.editor
+	if ed.deleteSelection() {
+		return
+	}
+	if ed.block {
+		ed.deleteBlockColumn(0)
+	} else {
+		ed.deleteRuneAtCursor()
+	}
+}
+
+// Calls OnCursorMove callback if cursor is not at prev position anymore.
+func (ebox *Editbox) cursorMoved(prev cursor) {
+	if ebox.onCursorMove != nil && ebox.editor.cursor != prev {
+		ebox.onCursorMove(ebox.editor.position())
+	}
 }
 
 //----------------------------------------------------------------------------
 // API
 //----------------------------------------------------------------------------
 
-// Set widget content
+// Set widget content. Text set from code cannot be undone,
+// undo history is cleared.
 func (ebox *Editbox) SetText(s string) {
+	defer ebox.cursorMoved(ebox.editor.cursor)
 	ebox.editor.setText(s)
+	ebox.editor.clearUndo()
 }
 
 // Returns widget content.
@@ -275,16 +420,20 @@ func (ebox *Editbox) Text() string {
 	return ebox.editor.text()
 }
 
-// Returns cursor position.
+// Returns cursor column and row after wrapping, like VisualCursor
+// but in x, y order. Note that SetCursor takes logical coordinates,
+// see LogicalCursor.
 func (ebox *Editbox) GetCursor() (int, int) {
 	return ebox.cursor.x, ebox.cursor.y
 }
 
-// Set cursor position
+// Set cursor to column x of line y. Removes additional cursors.
 func (ebox *Editbox) SetCursor(x, y int) {
 	ed := ebox.editor
+	defer ebox.cursorMoved(ed.cursor)
+	ed.clearCarets()
 	if y > len(ed.lines)-1 {
-		ed.cursor.y = len(ed.lines)-1
+		ed.cursor.y = len(ed.lines) - 1
 	} else if y < 0 {
 		ed.cursor.y = 0
 	} else {
@@ -301,11 +450,15 @@ func (ebox *Editbox) SetCursor(x, y int) {
 	ed.lastx = ed.cursor.x
 }
 
-// Puts widget contents into termbox' cell buffer.
-// This function DOES NOT call termbox.Flush().
+// Puts widget contents into screen cell buffer.
+// This function DOES NOT flush screen.
 func (ebox *Editbox) Render() {
 	ebox.renderView()
 	var r rune
+	fg, bg := ebox.fg, ebox.bg
+	if ebox.readOnly {
+		fg, bg = ebox.rofg, ebox.robg
+	}
 	for y := 0; y < ebox.height; y++ {
 		for x := 0; x < ebox.width; x++ {
 			if ebox.view[y][x] != 0 {
@@ -313,106 +466,253 @@ func (ebox *Editbox) Render() {
 			} else {
 				r = ' ' // Fill empty cells with background color
 			}
-			termbox.SetCell(ebox.x+x, ebox.y+y, r, ebox.fg, ebox.bg)
+			switch ebox.highlights[y][x] {
+			case hlSelection:
+				screen.SetCell(ebox.x+x, ebox.y+y, r, fg|termbox.AttrReverse, bg)
+			case hlBracket:
+				screen.SetCell(ebox.x+x, ebox.y+y, r,
+					fg|termbox.AttrBold|termbox.AttrUnderline, bg)
+			case hlCursor:
+				screen.SetCell(ebox.x+x, ebox.y+y, r,
+					fg|termbox.AttrReverse|termbox.AttrBold, bg)
+			default:
+				screen.SetCell(ebox.x+x, ebox.y+y, r, fg, bg)
+			}
 		}
 	}
-	termbox.SetCursor(ebox.x+ebox.cursor.x-ebox.scroll.x,
-		ebox.y+ebox.cursor.y-ebox.scroll.y)
+	if ebox.blurred {
+		return
+	}
+	// Cursor may be scrolled out of view by mouse wheel
+	x, y := ebox.cursor.x-ebox.scroll.x, ebox.cursor.y-ebox.scroll.y
+	if x < 0 || x >= ebox.width || y < 0 || y >= ebox.height {
+		hideCursor()
+		return
+	}
+	screen.SetCursor(ebox.x+x, ebox.y+y)
 }
 
 // Processes termbox events.
 // Useful if you poll them by yourself.
-func (ebox *Editbox) HandleEvent(ev termbox.Event) {
+// Returns false if event is not used by widget. Exit keys are not used.
+func (ebox *Editbox) HandleEvent(ev termbox.Event) bool {
 	ed := ebox.editor
+	defer ebox.cursorMoved(ed.cursor)
+	ed.beginUndo()
+	defer ed.endUndo()
+	// Events may come faster than Render updates wrapped lines
+	ebox.syncLines()
+	defer ebox.syncLines()
 	switch ev.Type {
 	case termbox.EventKey:
+		if ev.Ch == 0 && ev.Mod&termbox.ModAlt == 0 &&
+			hasKey(ebox.exitKeys, ev.Key) {
+			return false
+		}
+		ebox.freeScroll = false
+		if ev.Mod&termbox.ModAlt != 0 {
+			return ebox.handleAltKey(ev)
+		}
+		hist := ebox.history != nil && ebox.virtualHeight == 1 &&
+			!ebox.readOnly
+		if hist && ev.Key != termbox.KeyArrowUp &&
+			ev.Key != termbox.KeyArrowDown && ev.Key != termbox.KeyCtrlR {
+			ebox.history.reset()
+		}
+		// Printable runes come with zero key which is KeyCtrlSpace
+		if ev.Ch != 0 {
+			ebox.insertRuneAtCursors(ev.Ch)
+			return true
+		}
 		switch ev.Key {
 		case termbox.KeyArrowLeft:
-			ed.moveCursorLeft()
+			ed.forEachCursor(ed.moveCursorLeft)
 		case termbox.KeyArrowRight:
-			ed.moveCursorRight()
+			ed.forEachCursor(ed.moveCursorRight)
 		case termbox.KeyArrowUp:
-			if ebox.virtualHeight == 1 {
+			ed.clearCarets()
+			if hist {
+				ebox.historyPrev()
+			} else if ebox.virtualHeight == 1 {
 				ed.moveCursorToLineStart()
 			} else {
 				ebox.moveCursorUp()
 			}
 		case termbox.KeyArrowDown:
-			if ebox.virtualHeight == 1 {
+			ed.clearCarets()
+			if hist {
+				ebox.historyNext()
+			} else if ebox.virtualHeight == 1 {
 				ed.moveCursorToLineEnd()
 			} else {
 				ebox.moveCursorDown()
 			}
 		case termbox.KeyHome:
-			ed.moveCursorToLineStart()
+			ed.forEachCursor(ed.moveCursorToLineStart)
 		case termbox.KeyEnd:
-			ed.moveCursorToLineEnd()
+			ed.forEachCursor(ed.moveCursorToLineEnd)
 		case termbox.KeyPgup:
+			ed.clearCarets()
 			ebox.moveCursorPageUp()
 		case termbox.KeyPgdn:
+			ed.clearCarets()
 			ebox.moveCursorPageDown()
+		case termbox.KeyCtrlSpace:
+			ebox.ToggleSelection()
+		case termbox.KeyCtrlA:
+			ebox.SelectAll()
+		case termbox.KeyCtrlC:
+			ebox.Copy()
+		case termbox.KeyCtrlX:
+			ebox.Cut()
+		case termbox.KeyCtrlV:
+			ebox.Paste()
+		case termbox.KeyCtrlZ:
+			ebox.Undo()
+		case termbox.KeyCtrlB:
+			ebox.ToggleBlockSelection()
+		case termbox.KeyCtrlP:
+			ebox.AddCursorAbove()
+		case termbox.KeyCtrlN:
+			ebox.AddCursorBelow()
+		case termbox.KeyCtrlD:
+			ebox.AddCursorAtNextOccurrence()
 		case termbox.KeyBackspace, termbox.KeyBackspace2:
-			ed.deleteRuneBeforeCursor()
+			ed.forEachCursor(ebox.deleteRuneBeforeCursor)
 		case termbox.KeyDelete:
-			ed.deleteRuneAtCursor()
+			ed.forEachCursor(ebox.deleteRuneAtCursor)
 		case termbox.KeyEnter:
-			ed.insertRune('\n')
+			ebox.insertRuneAtCursors('\n')
 		case termbox.KeySpace:
-			ed.insertRune(' ')
-		default:
-			if ev.Ch != 0 {
-				ed.insertRune(ev.Ch)
+			ebox.insertRuneAtCursors(' ')
+		case termbox.KeyCtrlRsqBracket:
+			ebox.JumpToMatchingBracket()
+		case termbox.KeyCtrlR:
+			if !hist {
+				return false
 			}
+			ebox.historySearch()
+		default:
+			return false
 		}
-	case termbox.EventError:
-		panic(ev.Err)
+	case termbox.EventMouse:
+		return ebox.handleMouse(ev)
+	case termbox.EventResize:
+		ebox.handleResize(ev)
 	default:
-		// TODO
+		return false
 	}
+	return true
+}
+
+// Alt key combinations. They are reported by termbox
+// only in termbox.InputAlt input mode.
+func (ebox *Editbox) handleAltKey(ev termbox.Event) bool {
+	switch {
+	case ev.Key == termbox.KeyArrowUp:
+		ebox.MoveLineUp()
+	case ev.Key == termbox.KeyArrowDown:
+		ebox.MoveLineDown()
+	case ev.Ch == 'd':
+		ebox.DuplicateLine()
+	case ev.Ch == 'k':
+		ebox.DeleteLine()
+	case ev.Ch == 'j':
+		ebox.JoinLines()
+	case ev.Ch == 's':
+		ebox.SortLines()
+	case ev.Ch == 'u':
+		ebox.UpperCase()
+	case ev.Ch == 'l':
+		ebox.LowerCase()
+	case ev.Ch == 'c':
+		ebox.TitleCase()
+	case ev.Ch == 'q':
+		if ebox.reflowWidth > 0 {
+			ebox.Reflow(ebox.reflowWidth)
+		} else {
+			ebox.Reflow(ebox.width)
+		}
+	default:
+		return false
+	}
+	return true
 }
 
 // Start listen for termbox events and edit text.
 // Blocks until exit event. Returns event which made Editbox to exit.
-func (ebox *Editbox) WaitExit() termbox.Event {
-	// Buffered channel processes paste from buffer faster
-	// because render is called less often
-	events := make(chan termbox.Event, 256)
-	exitEvent := make(chan termbox.Event)
-	go func() {
-		for {
-			ev := termbox.PollEvent()
-			if ev.Type == termbox.EventKey {
-				for _, key := range ebox.exitKeys {
-					if ev.Key == key {
-						exitEvent <- ev
-						return
-					}
-				}
-			}
-			events <- ev
-		}
-	}()
-	ebox.Render()
-	termbox.Flush()
-	for {
-		select {
-		case ev := <-events:
-			ebox.HandleEvent(ev)
-			// re-render on empty events buffer
-			if len(events) == 0 {
-				ebox.Render()
-				termbox.Flush()
-			}
-		case ev := <-exitEvent:
-			return ev
-		}
+// If history is enabled and Editbox exits with Enter text is saved
+// to history.
+// Mouse click outside of widget also makes it exit.
+// Returns *TerminalError on termbox error event. Returns error of
+// History.Add as is if text cannot be saved to history.
+func (ebox *Editbox) WaitExit() (termbox.Event, error) {
+	return ebox.WaitExitContext(context.Background())
+}
+
+// Same as WaitExit but also returns ctx.Err() when ctx is cancelled
+// or its deadline is exceeded.
+func (ebox *Editbox) WaitExitContext(ctx context.Context) (termbox.Event, error) {
+	ev, err := waitExit(ctx, ebox.isExit,
+		func(ev termbox.Event) { ebox.HandleEvent(ev) }, ebox.Render)
+	if err == nil && ev.Type == termbox.EventKey && ev.Key == termbox.KeyEnter {
+		err = ebox.SaveHistory()
+	}
+	return ev, err
+}
+
+// Returns true if event makes WaitExit return
+func (ebox *Editbox) isExit(ev termbox.Event) bool {
+	if ev.Type == termbox.EventKey {
+		// Printable runes come with zero key
+		return ev.Ch == 0 && ev.Mod&termbox.ModAlt == 0 &&
+			hasKey(ebox.exitKeys, ev.Key)
 	}
+	return ev.Type == termbox.EventMouse && ev.Key == termbox.MouseLeft &&
+		ev.Mod&termbox.ModMotion == 0 && !ebox.contains(ev.MouseX, ev.MouseY)
 }
 
 func (ebox *Editbox) AddExitKeys(keys ...termbox.Key) {
 	ebox.exitKeys = append(ebox.exitKeys, keys...)
 }
 
+// Makes widget read-only. Cursor movement, scrolling, selection and copy
+// still work but content cannot be changed by user.
+// Read-only widget is rendered with its own colors, see SetReadOnlyColors.
+func (ebox *Editbox) SetReadOnly(readOnly bool) {
+	ebox.readOnly = readOnly
+}
+
+func (ebox *Editbox) ReadOnly() bool {
+	return ebox.readOnly
+}
+
+// Sets colors used in read-only mode.
+// By default widget colors are used with dimmed foreground.
+func (ebox *Editbox) SetReadOnlyColors(fg, bg termbox.Attribute) {
+	ebox.rofg = fg
+	ebox.robg = bg
+}
+
+// Sets rune displayed instead of every rune of text, like '*' for
+// passwords. Masked text cannot be copied to clipboard.
+// Zero rune shows text as is.
+func (ebox *Editbox) SetMask(r rune) {
+	ebox.mask = r
+}
+
+// Sets function called after every modification of widget content.
+// Useful for live validation or updating dependent widgets.
+func (ebox *Editbox) OnChange(f func(Change)) {
+	ebox.editor.onChange = f
+}
+
+// Sets function called every time cursor moves to new position
+// in widget content.
+func (ebox *Editbox) OnCursorMove(f func(Position)) {
+	ebox.onCursorMove = f
+}
+
 //----------------------------------------------------------------------------
 // Widgets
 //----------------------------------------------------------------------------
@@ -429,11 +729,11 @@ func Label(x, y, width int, fg, bg termbox.Attribute, text string) {
 		if width > 0 && i >= width {
 			break
 		}
-		termbox.SetCell(x+i, y, r, fg, bg)
+		screen.SetCell(x+i, y, r, fg, bg)
 	}
 	// Fill the rest of the width with spaces
 	for i = i + 1; width > 0 && i < width; i++ {
-		termbox.SetCell(x+i, y, ' ', fg, bg)
+		screen.SetCell(x+i, y, ' ', fg, bg)
 	}
 }
 
@@ -459,9 +759,15 @@ func Text(x, y, width, height int, fg, bg termbox.Attribute, text string) {
 	}
 }
 
-// Create new Input widget. This DOES NOT call termbox.Flush().
+// Create new Input widget. This DOES NOT flush screen.
 func Input(x, y, width int, fg, bg termbox.Attribute) *Editbox {
-	ebox := newEditbox(x, y, width, 1, options{
+	ebox := newInput(x, y, width, fg, bg)
+	ebox.Render()
+	return ebox
+}
+
+func newInput(x, y, width int, fg, bg termbox.Attribute) *Editbox {
+	return newEditbox(x, y, width, 1, options{
 		fg:   fg,
 		bg:   bg,
 		wrap: false,
@@ -472,15 +778,32 @@ func Input(x, y, width int, fg, bg termbox.Attribute) *Editbox {
 		},
 		autoexpand: false,
 	})
+}
+
+// Create new Input widget which displays '*' instead of text.
+// This DOES NOT flush screen.
+func Password(x, y, width int, fg, bg termbox.Attribute) *Editbox {
+	ebox := newInput(x, y, width, fg, bg)
+	ebox.mask = '*'
 	ebox.Render()
 	return ebox
 }
 
-// Create new Textarea widget. This DOES NOT call termbox.Flush().
+// Create new Textarea widget. This DOES NOT flush screen.
 func Textarea(
 	x, y, width, height int,
 	fg, bg termbox.Attribute,
 	wrap bool,
+) *Editbox {
+	ebox := newTextarea(x, y, width, height, fg, bg, wrap)
+	ebox.Render()
+	return ebox
+}
+
+func newTextarea(
+	x, y, width, height int,
+	fg, bg termbox.Attribute,
+	wrap bool,
 ) *Editbox {
 	ebox := newEditbox(x, y, width, height, options{
 		fg:   fg,
@@ -492,25 +815,88 @@ func Textarea(
 		},
 		autoexpand: false,
 	})
-	ebox.Render()
+	ebox.brackets = defaultBrackets
 	return ebox
 }
 
-// Confirmation dialog
-func Confirm(x, y int, fg, bg termbox.Attribute, msg string) (bool, termbox.Event) {
+// Confirmation dialog. Returns true if user pressed y and event which
+// closed dialog. Returns *TerminalError on termbox error event.
+//
+// With buttons msg is followed by them like <Yes>  <No> in whiptail.
+// Tab and arrows move focus, Enter or Space presses focused button and
+// first letter of label presses its button. Returns true if the first
+// button is pressed. Esc closes dialog with false.
+func Confirm(
+	x, y int,
+	fg, bg termbox.Attribute,
+	msg string,
+	buttons ...string,
+) (bool, termbox.Event, error) {
+	if len(buttons) > 0 {
+		return confirmButtons(x, y, fg, bg, msg, buttons)
+	}
 	Label(x, y, 0, fg, bg, msg+" [y/n]")
-	termbox.Flush()
+	screen.Flush()
 	for {
-		ev := termbox.PollEvent()
+		ev := eventSource.PollEvent()
 		if ev.Type == termbox.EventKey && ev.Ch == 'y' {
-			return true, ev
+			return true, ev, nil
 		}
 		if ev.Type == termbox.EventKey &&
 			(ev.Key == termbox.KeyEsc || ev.Ch == 'n') {
-			return false, ev
+			return false, ev, nil
 		}
 		if ev.Type == termbox.EventError {
-			panic(ev.Err)
+			return false, ev, &TerminalError{ev.Err}
+		}
+	}
+}
+
+func confirmButtons(
+	x, y int,
+	fg, bg termbox.Attribute,
+	msg string,
+	buttons []string,
+) (bool, termbox.Event, error) {
+	hideCursor()
+	focus := 0
+	for {
+		bx := x
+		if msg != "" {
+			Label(bx, y, 0, fg, bg, msg)
+			bx += len([]rune(msg)) + 2
+		}
+		for i, b := range buttons {
+			// Focused button is drawn in reverse colors
+			if i == focus {
+				Label(bx, y, 0, bg, fg, "<"+b+">")
+			} else {
+				Label(bx, y, 0, fg, bg, "<"+b+">")
+			}
+			bx += len([]rune(b)) + 4
+		}
+		screen.Flush()
+		ev := eventSource.PollEvent()
+		switch {
+		case ev.Type == termbox.EventError:
+			return false, ev, &TerminalError{ev.Err}
+		case ev.Type != termbox.EventKey:
+		case ev.Ch != 0:
+			for i, b := range buttons {
+				if unicode.ToLower(ev.Ch) == unicode.ToLower([]rune(b)[0]) {
+					return i == 0, ev, nil
+				}
+			}
+		case ev.Key == termbox.KeyEnter || ev.Key == termbox.KeySpace:
+			return focus == 0, ev, nil
+		case ev.Key == termbox.KeyEsc:
+			return false, ev, nil
+		case ev.Key == termbox.KeyTab || ev.Key == termbox.KeyArrowRight ||
+			ev.Key == termbox.KeyArrowDown:
+			focus = (focus + 1) % len(buttons)
+		case ev.Key == KeyBacktab || ev.Key == termbox.KeyArrowLeft ||
+			ev.Key == termbox.KeyArrowUp:
+			focus = (focus + len(buttons) - 1) % len(buttons)
 		}
 	}
 }
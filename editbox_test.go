@@ -1,6 +1,8 @@
 package editbox
 
 import (
+	"errors"
+	"github.com/nsf/termbox-go"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -251,3 +253,75 @@ func TestMoveUp(t *testing.T) {
 	assert.Equal(t, eb.cursor.x, 2)
 	assert.Equal(t, eb.cursor.y, 0)
 }
+
+func TestOnCursorMove(t *testing.T) {
+	eb := newEditbox(0, 0, 3, 3, options{wrap: true})
+	moves := []Position{}
+	eb.OnCursorMove(func(p Position) {
+		moves = append(moves, p)
+	})
+	eb.SetText("12\n3")
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyArrowLeft)
+	sendKey(eb, termbox.KeyArrowLeft) // Wraps to previous line
+	sendKey(eb, termbox.KeyArrowRight)
+	eb.SetCursor(5, 0)
+	eb.SetCursor(5, 0) // Not moved
+	assert.Equal(t, moves, []Position{
+		{1, 1},
+		{1, 0},
+		{0, 2},
+		{1, 0},
+		{0, 2},
+	})
+}
+
+func TestMask(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 1, options{})
+	eb.SetText("secret")
+	eb.SetMask('*')
+	eb.renderView()
+	assert.Equal(t, string(eb.view[0][:6]), "******")
+	eb.SelectAll()
+	SetClipboardText("foo")
+	eb.Copy()
+	assert.Equal(t, ClipboardText(), "foo")
+}
+
+func TestEventErrorIgnored(t *testing.T) {
+	eb := newTestInput(0, 0, 10)
+	assert.False(t, eb.HandleEvent(termbox.Event{
+		Type: termbox.EventError, Err: errors.New("foo"),
+	}))
+	form := newTestForm()
+	done, submitted := form.HandleEvent(termbox.Event{
+		Type: termbox.EventError, Err: errors.New("foo"),
+	})
+	assert.False(t, done)
+	assert.False(t, submitted)
+	err := error(&TerminalError{errors.New("foo")})
+	assert.Equal(t, err.Error(), "editbox: terminal: foo")
+	assert.Equal(t, errors.Unwrap(err).Error(), "foo")
+}
+
+func TestHandleEventRecountsLinesAfterChange(t *testing.T) {
+	eb := newEditbox(0, 0, 3, 3, options{wrap: true})
+	key := func(ev termbox.Event) {
+		ev.Type = termbox.EventKey
+		eb.HandleEvent(ev)
+	}
+	for _, r := range "abcdefg" {
+		key(termbox.Event{Ch: r})
+	}
+	key(termbox.Event{Key: termbox.KeyArrowUp})
+	assert.Equal(t, eb.virtualHeight, 3)
+	assert.Equal(t, eb.editor.cursor, cursor{4, 0})
+	assert.Equal(t, eb.cursor, cursor{1, 1})
+
+	// Cursor movement does not recount lines
+	offsets := eb.lineBoxY
+	key(termbox.Event{Key: termbox.KeyArrowLeft})
+	key(termbox.Event{Key: termbox.KeyArrowLeft})
+	assert.True(t, &offsets[0] == &eb.lineBoxY[0])
+	assert.Equal(t, eb.cursor, cursor{2, 0})
+}
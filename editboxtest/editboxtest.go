@@ -0,0 +1,243 @@
+// Package editboxtest helps to test applications built on editbox.
+// Widgets draw on in-memory screen and read scripted events:
+//
+//	func TestName(t *testing.T) {
+//		s := editboxtest.New(t, 40, 5)
+//		input := editbox.Input(0, 0, 20, termbox.ColorWhite, termbox.ColorBlue)
+//		s.Type("John").Press(termbox.KeyEnter)
+//		s.Wait(input)
+//		s.AssertText(input, "John")
+//		s.AssertLine(0, "John")
+//	}
+//
+// Script must end with key which makes widget exit, otherwise Wait
+// fails test with io.EOF terminal error.
+package editboxtest
+
+import (
+	"context"
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Default time widget has to exit in
+var Timeout = time.Second
+
+// Widget which waits for exit event, e.g. Input or Select
+type Waiter interface {
+	WaitExit() (termbox.Event, error)
+}
+
+// Waiter which can be cancelled. Wait uses WaitExitContext when widget
+// implements it, so widget is stopped on timeout.
+type ContextWaiter interface {
+	WaitExitContext(ctx context.Context) (termbox.Event, error)
+}
+
+// In-memory screen installed for the duration of test
+type Screen struct {
+	*editbox.MemoryScreen
+	t testing.TB
+}
+
+// Screen and event source are package globals of editbox, so only one
+// test may use them at a time. owner is name of test which installed
+// screen, users counts screens installed by it and its subtests.
+var (
+	mutex sync.Mutex
+	owner string
+	users int
+)
+
+// Creates in-memory screen of width x height and makes widgets draw on it
+// and read events from it. Previous screen is restored when test ends.
+//
+// Screen is global to editbox package, so tests which call New MUST NOT
+// run in parallel. New fails test if screen is used by another test.
+func New(t testing.TB, width, height int) *Screen {
+	t.Helper()
+	mutex.Lock()
+	if users > 0 && t.Name() != owner &&
+		!strings.HasPrefix(t.Name(), owner+"/") {
+		mutex.Unlock()
+		t.Fatalf("editboxtest: screen is used by %s, "+
+			"tests which call New cannot run in parallel", owner)
+	}
+	if users == 0 {
+		owner = t.Name()
+	}
+	users++
+	mutex.Unlock()
+	oldScreen, oldEvents := editbox.GetScreen(), editbox.GetEventSource()
+	t.Cleanup(func() {
+		editbox.SetScreen(oldScreen)
+		editbox.SetEventSource(oldEvents)
+		mutex.Lock()
+		users--
+		mutex.Unlock()
+	})
+	s := &Screen{MemoryScreen: editbox.NewMemoryScreen(width, height), t: t}
+	editbox.SetScreen(s.MemoryScreen)
+	return s
+}
+
+// Appends key events of every rune of text to script.
+func (s *Screen) Type(text string) *Screen {
+	s.ScriptEvents.Type(text)
+	return s
+}
+
+// Appends key events of keys to script.
+func (s *Screen) Press(keys ...termbox.Key) *Screen {
+	s.ScriptEvents.Press(keys...)
+	return s
+}
+
+// Appends left click at x, y to script.
+func (s *Screen) Click(x, y int) *Screen {
+	s.Add(
+		termbox.Event{Type: termbox.EventMouse, Key: termbox.MouseLeft,
+			MouseX: x, MouseY: y},
+		termbox.Event{Type: termbox.EventMouse, Key: termbox.MouseRelease,
+			MouseX: x, MouseY: y},
+	)
+	return s
+}
+
+// Runs f in goroutine and fails test if f does not return in Timeout.
+// Use it for Confirm, Form.Run and other event loops.
+//
+// f cannot be cancelled. On timeout screen is interrupted and Run waits
+// another Timeout for f to return, so f must stop on
+// termbox.EventInterrupt or on io.EOF error of ended script. Form.Run and
+// Confirm stop on the latter. Test fails if f does not return.
+func (s *Screen) Run(f func()) {
+	s.t.Helper()
+	if !s.run(f, Timeout) {
+		s.t.Fatalf("editboxtest: timeout %v exceeded", Timeout)
+	}
+}
+
+// Returns false on timeout. f keeps running after timeout, so it is
+// interrupted and waited for not to race with screen restored on cleanup.
+// Fails test if f ignores interrupt.
+func (s *Screen) run(f func(), timeout time.Duration) bool {
+	s.t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		s.Interrupt()
+	}
+	select {
+	case <-done:
+		return false
+	case <-time.After(timeout):
+		s.t.Fatalf("editboxtest: function did not return in %v "+
+			"after interrupt", timeout)
+		return false
+	}
+}
+
+// Runs widget WaitExit in goroutine until script makes it exit.
+// Fails test on error or if widget does not exit in Timeout.
+// Returns event which made widget exit.
+func (s *Screen) Wait(w Waiter) termbox.Event {
+	s.t.Helper()
+	return s.WaitTimeout(w, Timeout)
+}
+
+// Same as Wait with custom timeout.
+func (s *Screen) WaitTimeout(w Waiter, timeout time.Duration) termbox.Event {
+	s.t.Helper()
+	var (
+		ev  termbox.Event
+		err error
+	)
+	cw, ok := w.(ContextWaiter)
+	if !ok {
+		if !s.run(func() { ev, err = w.WaitExit() }, timeout) {
+			s.t.Fatalf("editboxtest: widget did not exit in %v", timeout)
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		// Widget returns on timeout by itself, run guards against
+		// widgets ignoring context
+		if !s.run(func() { ev, err = cw.WaitExitContext(ctx) }, 2*timeout) ||
+			err == context.DeadlineExceeded {
+			s.t.Fatalf("editboxtest: widget did not exit in %v", timeout)
+		}
+	}
+	if err != nil {
+		s.t.Fatalf("editboxtest: %v", err)
+	}
+	return ev
+}
+
+// Checks widget text
+func (s *Screen) AssertText(w interface{ Text() string }, expected string) bool {
+	s.t.Helper()
+	if actual := w.Text(); actual != expected {
+		s.t.Errorf("editboxtest: text is %q, expected %q", actual, expected)
+		return false
+	}
+	return true
+}
+
+// Checks screen text without trailing spaces and empty lines.
+func (s *Screen) AssertScreen(expected string) bool {
+	s.t.Helper()
+	if actual := s.String(); actual != expected {
+		s.t.Errorf("editboxtest: screen is\n%s\nexpected\n%s",
+			frame(actual), frame(expected))
+		return false
+	}
+	return true
+}
+
+// Checks screen line y without trailing spaces.
+func (s *Screen) AssertLine(y int, expected string) bool {
+	s.t.Helper()
+	_, height := s.Size()
+	lines := strings.Split(s.String(), "\n")
+	actual := ""
+	if y < len(lines) {
+		actual = lines[y]
+	}
+	if y < 0 || y >= height || actual != expected {
+		s.t.Errorf("editboxtest: line %d is %q, expected %q", y, actual, expected)
+		return false
+	}
+	return true
+}
+
+// Checks cursor position. Hidden cursor is at -1, -1.
+func (s *Screen) AssertCursor(x, y int) bool {
+	s.t.Helper()
+	if ax, ay := s.Cursor(); ax != x || ay != y {
+		s.t.Errorf("editboxtest: cursor is at %d,%d, expected %d,%d",
+			ax, ay, x, y)
+		return false
+	}
+	return true
+}
+
+// Outlines text to show trailing spaces and empty lines
+func frame(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf("|%s|", line)
+	}
+	return strings.Join(lines, "\n")
+}
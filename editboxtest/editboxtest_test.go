@@ -0,0 +1,211 @@
+package editboxtest
+
+import (
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+// Records failures instead of failing test
+type fakeT struct {
+	testing.TB
+	name   string
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Name() string {
+	if f.name != "" {
+		return f.name
+	}
+	return f.TB.Name()
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.Errorf(format, args...)
+	f.fatal = true
+	runtime.Goexit()
+}
+
+// Runs f with fake test in goroutine, so Fatalf stops only f
+func withFakeT(t *testing.T, f func(ft *fakeT)) *fakeT {
+	return withFakeTest(&fakeT{TB: t}, f)
+}
+
+func withFakeTest(ft *fakeT, f func(ft *fakeT)) *fakeT {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(ft)
+	}()
+	<-done
+	return ft
+}
+
+// Ignores script and exits only when interrupted
+type stuckWidget struct{}
+
+func (stuckWidget) WaitExit() (termbox.Event, error) {
+	for {
+		ev := editbox.GetEventSource().PollEvent()
+		if ev.Type == termbox.EventInterrupt {
+			return ev, nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestInput(t *testing.T) {
+	s := New(t, 20, 2)
+	input := editbox.Input(1, 1, 10, termbox.ColorWhite, termbox.ColorBlue)
+	s.Type("Hello").Press(termbox.KeyArrowLeft, termbox.KeyEnter)
+	ev := s.Wait(input)
+	assert.Equal(t, ev.Key, termbox.KeyEnter)
+	s.AssertText(input, "Hello")
+	s.AssertScreen("\n Hello")
+	s.AssertLine(1, " Hello")
+	s.AssertCursor(5, 1)
+	assert.Equal(t, s.Cell(1, 1).Bg, termbox.ColorBlue)
+}
+
+func TestSelect(t *testing.T) {
+	s := New(t, 10, 3)
+	sbox := editbox.Select(0, 0, 10, 3, 0, 0, 0, 0,
+		[]string{"foo", "bar", "baz"})
+	s.Press(termbox.KeyArrowDown, termbox.KeyEnter)
+	s.Wait(sbox)
+	s.AssertText(sbox, "bar")
+	s.AssertScreen("foo\nbar\nbaz")
+}
+
+func TestForm(t *testing.T) {
+	s := New(t, 20, 2)
+	form := editbox.NewForm()
+	form.AddEditbox("first", editbox.Input(0, 0, 10, 0, 0))
+	form.AddEditbox("last", editbox.Input(0, 1, 10, 0, 0))
+	s.Type("John").Press(termbox.KeyTab).Type("Doe").Press(termbox.KeyEnter)
+	var submitted bool
+	var err error
+	s.Run(func() {
+		submitted, _, err = form.Run()
+	})
+	assert.Nil(t, err)
+	assert.True(t, submitted)
+	assert.Equal(t, form.Values(),
+		map[string]string{"first": "John", "last": "Doe"})
+	s.AssertScreen("John\nDoe")
+}
+
+func TestConfirmAndClick(t *testing.T) {
+	s := New(t, 20, 1)
+	s.Click(3, 0).Type("y")
+	var yes bool
+	s.Run(func() {
+		yes, _, _ = editbox.Confirm(0, 0, 0, 0, "Quit")
+	})
+	assert.True(t, yes)
+	s.AssertLine(0, "Quit [y/n]")
+	assert.Equal(t, s.Len(), 0)
+}
+
+func TestScreenRestored(t *testing.T) {
+	before := editbox.GetScreen()
+	t.Run("sub", func(t *testing.T) {
+		s := New(t, 1, 1)
+		assert.Equal(t, editbox.GetScreen(), editbox.Screen(s.MemoryScreen))
+	})
+	assert.Equal(t, editbox.GetScreen(), before)
+}
+
+func TestFailures(t *testing.T) {
+	ft := withFakeT(t, func(ft *fakeT) {
+		s := New(ft, 10, 1)
+		input := editbox.Input(0, 0, 10, 0, 0)
+		s.Type("abc")
+		s.Wait(input)
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, ft.errors, []string{"editboxtest: editbox: terminal: EOF"})
+
+	ft = withFakeT(t, func(ft *fakeT) {
+		s := New(ft, 10, 1)
+		s.WaitTimeout(stuckWidget{}, 10*time.Millisecond)
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, ft.errors,
+		[]string{"editboxtest: widget did not exit in 10ms"})
+
+	defer func(d time.Duration) { Timeout = d }(Timeout)
+	Timeout = 10 * time.Millisecond
+	var returned bool
+	ft = withFakeT(t, func(ft *fakeT) {
+		s := New(ft, 10, 1)
+		s.Run(func() {
+			stuckWidget{}.WaitExit()
+			returned = true
+		})
+	})
+	assert.True(t, returned)
+	assert.Equal(t, ft.errors, []string{"editboxtest: timeout 10ms exceeded"})
+
+	// Function ignores interrupt
+	release := make(chan struct{})
+	defer close(release)
+	ft = withFakeT(t, func(ft *fakeT) {
+		s := New(ft, 10, 1)
+		s.Run(func() { <-release })
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, ft.errors, []string{
+		"editboxtest: function did not return in 10ms after interrupt",
+	})
+
+	ft = withFakeT(t, func(ft *fakeT) {
+		s := New(ft, 10, 2)
+		editbox.Label(0, 0, 0, 0, 0, "abc ")
+		assert.False(t, s.AssertScreen("ab"))
+		assert.False(t, s.AssertLine(1, "x"))
+		assert.False(t, s.AssertLine(5, ""))
+		assert.False(t, s.AssertCursor(0, 0))
+		assert.False(t, s.AssertText(editbox.NewLabel(0, 0, 0, 0, 0, "a"), "b"))
+	})
+	assert.False(t, ft.fatal)
+	assert.Equal(t, ft.errors, []string{
+		"editboxtest: screen is\n|abc|\nexpected\n|ab|",
+		`editboxtest: line 1 is "", expected "x"`,
+		`editboxtest: line 5 is "", expected ""`,
+		"editboxtest: cursor is at -1,-1, expected 0,0",
+		`editboxtest: text is "a", expected "b"`,
+	})
+}
+
+func TestConcurrentNew(t *testing.T) {
+	New(t, 1, 1)
+	t.Run("sub", func(t *testing.T) {
+		New(t, 1, 1)
+	})
+	ft := withFakeTest(&fakeT{TB: t, name: "TestOther"}, func(ft *fakeT) {
+		New(ft, 1, 1)
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, ft.errors, []string{
+		"editboxtest: screen is used by TestConcurrentNew, " +
+			"tests which call New cannot run in parallel",
+	})
+}
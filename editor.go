@@ -5,9 +5,25 @@ import (
 )
 
 type editor struct {
-	lines  []line
-	cursor cursor
-	lastx  int
+	lines    []line
+	cursor   cursor
+	lastx    int
+	onChange func(Change)
+	// Suppresses change notifications during batch edits
+	muted bool
+	// Text changed since wrapped lines were counted
+	changed bool
+	// Selection start. See selection.go
+	anchor    cursor
+	selecting bool
+	block     bool
+	// Additional cursors. See multicursor.go
+	carets []caret
+	// Undo steps. See undo.go
+	undo      [][]Change
+	undoGroup []Change
+	undoDepth int
+	undoing   bool
 }
 
 func newEditor() *editor {
@@ -15,9 +31,44 @@ func newEditor() *editor {
 	ed.lines = make([]line, 1)
 	ed.cursor.x = 0
 	ed.cursor.y = 0
+	ed.changed = true
 	return &ed
 }
 
+func (ed *editor) notify(c Change) {
+	ed.changed = true
+	if ed.muted {
+		return
+	}
+	ed.recordUndo(c)
+	if ed.onChange != nil {
+		ed.onChange(c)
+	}
+}
+
+func (ed *editor) position() Position {
+	return Position{Line: ed.cursor.y, Col: ed.cursor.x}
+}
+
+func (ed *editor) endPosition() Position {
+	y := len(ed.lines) - 1
+	return Position{Line: y, Col: len(ed.lines[y].text)}
+}
+
+func (ed *editor) clear() {
+	deleted := ed.text()
+	end := ed.endPosition()
+	ed.lines = make([]line, 1)
+	ed.cursor.x = 0
+	ed.cursor.y = 0
+	ed.lastx = 0
+	ed.clearSelection()
+	ed.clearCarets()
+	if deleted != "" {
+		ed.notify(Change{End: end, Deleted: deleted})
+	}
+}
+
 func (ed *editor) text() string {
 	var b bytes.Buffer
 	for _, l := range ed.lines {
@@ -40,6 +91,7 @@ func (ed *editor) splitLine(x, y int) {
 }
 
 func (ed *editor) insertRune(r rune) {
+	start := ed.position()
 	cursor := &ed.cursor
 	line := ed.currentLine()
 	line.insertRune(cursor.x, r)
@@ -50,12 +102,7 @@ func (ed *editor) insertRune(r rune) {
 		cursor.x = 0
 	}
 	ed.lastx = cursor.x
-}
-
-func (ed *editor) checkYPosition(y int) {
-	if y < 0 || y > len(ed.lines) {
-		panic("y position out of range")
-	}
+	ed.notify(Change{Start: start, End: ed.position(), Inserted: string(r)})
 }
 
 func (ed *editor) deleteRuneBeforeCursor() {
@@ -71,6 +118,14 @@ func (ed *editor) deleteRuneAtCursor() {
 	cursor := &ed.cursor
 	l := ed.currentLine()
 	r := l.deleteRune(cursor.x)
+	if r == 0 {
+		return
+	}
+	start := ed.position()
+	end := Position{Line: cursor.y, Col: cursor.x + 1}
+	if r == '\n' {
+		end = Position{Line: cursor.y + 1, Col: 0}
+	}
 	if r == '\n' && cursor.y < len(ed.lines)-1 {
 		left := &ed.lines[cursor.y]
 		right := &ed.lines[cursor.y+1]
@@ -83,6 +138,7 @@ func (ed *editor) deleteRuneAtCursor() {
 			ed.lines = ed.lines[:len(ed.lines)-1]
 		}
 	}
+	ed.notify(Change{Start: start, End: end, Deleted: string(r)})
 }
 
 func (ed *editor) moveCursorRight() {
@@ -151,7 +207,14 @@ func (ed *editor) moveCursorVert(dy int) {
 
 // TODO Optimize
 func (ed *editor) setText(text string) {
+	start := ed.position()
+	muted := ed.muted
+	ed.muted = true
 	for _, s := range text {
 		ed.insertRune(rune(s))
 	}
+	ed.muted = muted
+	if text != "" {
+		ed.notify(Change{Start: start, End: ed.position(), Inserted: text})
+	}
 }
@@ -191,3 +191,42 @@ func TestMoveCursorToEmptyLine(t *testing.T) {
 }
 
 // TODO Add tests for cursor navigation
+
+func TestEditorOnChange(t *testing.T) {
+	ed := newEditor()
+	changes := []Change{}
+	ed.onChange = func(c Change) {
+		changes = append(changes, c)
+	}
+	ed.setText("12\n3")
+	ed.insertRune('\n')
+	ed.cursor.x = 2
+	ed.cursor.y = 0
+	ed.deleteRuneAtCursor()
+	ed.cursor.x = 0
+	ed.cursor.y = 1
+	ed.deleteRuneAtCursor() // No effect
+	ed.clear()
+	assert.Equal(t, changes, []Change{
+		{
+			Start:    Position{0, 0},
+			End:      Position{1, 1},
+			Inserted: "12\n3",
+		},
+		{
+			Start:    Position{1, 1},
+			End:      Position{2, 0},
+			Inserted: "\n",
+		},
+		{
+			Start:   Position{0, 2},
+			End:     Position{1, 0},
+			Deleted: "\n",
+		},
+		{
+			Start:   Position{0, 0},
+			End:     Position{1, 0},
+			Deleted: "123\n",
+		},
+	})
+}
@@ -0,0 +1,16 @@
+package editbox
+
+// Error reported by termbox in termbox.EventError event.
+// WaitExit, Confirm and Run return it instead of panicking. Errors of
+// History are not wrapped in it.
+type TerminalError struct {
+	Err error
+}
+
+func (e *TerminalError) Error() string {
+	return "editbox: terminal: " + e.Err.Error()
+}
+
+func (e *TerminalError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,156 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"io"
+	"sync"
+)
+
+// Source of events widgets read in WaitExit, Confirm and Form.Run
+type EventSource interface {
+	// Blocks until next event
+	PollEvent() termbox.Event
+	// Makes PollEvent return termbox.EventInterrupt.
+	// PollEvent may be blocked or called later.
+	Interrupt()
+}
+
+// Event source used by widgets. Set with SetEventSource.
+var eventSource EventSource = TermboxEvents{}
+
+// Makes widgets read events from src. Default source is TermboxEvents.
+func SetEventSource(src EventSource) {
+	eventSource = src
+}
+
+// Returns source widgets read events from.
+func GetEventSource() EventSource {
+	return eventSource
+}
+
+//----------------------------------------------------------------------------
+// Termbox
+//----------------------------------------------------------------------------
+
+// Reads events from termbox
+type TermboxEvents struct{}
+
+func (TermboxEvents) PollEvent() termbox.Event {
+	return termbox.PollEvent()
+}
+
+func (TermboxEvents) Interrupt() {
+	termbox.Interrupt()
+}
+
+//----------------------------------------------------------------------------
+// Channel
+//----------------------------------------------------------------------------
+
+// Reads events from channel, e.g. received from network session
+// or another event loop.
+type ChanEvents struct {
+	events    <-chan termbox.Event
+	interrupt chan struct{}
+}
+
+// Creates source of events from channel. When channel is closed
+// PollEvent returns termbox.EventError with io.EOF.
+func NewChanEvents(events <-chan termbox.Event) *ChanEvents {
+	return &ChanEvents{events: events, interrupt: make(chan struct{}, 1)}
+}
+
+func (src *ChanEvents) PollEvent() termbox.Event {
+	select {
+	case <-src.interrupt:
+		return termbox.Event{Type: termbox.EventInterrupt}
+	default:
+	}
+	select {
+	case ev, ok := <-src.events:
+		if !ok {
+			return termbox.Event{Type: termbox.EventError, Err: io.EOF}
+		}
+		return ev
+	case <-src.interrupt:
+		return termbox.Event{Type: termbox.EventInterrupt}
+	}
+}
+
+// Does not block. Interrupts made before PollEvent are merged
+// into one EventInterrupt.
+func (src *ChanEvents) Interrupt() {
+	select {
+	case src.interrupt <- struct{}{}:
+	default:
+	}
+}
+
+//----------------------------------------------------------------------------
+// Script
+//----------------------------------------------------------------------------
+
+// Returns prerecorded events, useful in tests
+type ScriptEvents struct {
+	mutex       sync.Mutex
+	events      []termbox.Event
+	interrupted bool
+}
+
+// Creates source of events which returns events one by one.
+// When events are over PollEvent returns termbox.EventError with io.EOF.
+func NewScriptEvents(events ...termbox.Event) *ScriptEvents {
+	return &ScriptEvents{events: events}
+}
+
+// Appends events to script.
+func (src *ScriptEvents) Add(events ...termbox.Event) *ScriptEvents {
+	src.mutex.Lock()
+	defer src.mutex.Unlock()
+	src.events = append(src.events, events...)
+	return src
+}
+
+// Appends key events of every rune of s.
+func (src *ScriptEvents) Type(s string) *ScriptEvents {
+	for _, r := range s {
+		src.Add(termbox.Event{Type: termbox.EventKey, Ch: r})
+	}
+	return src
+}
+
+// Appends key events of keys.
+func (src *ScriptEvents) Press(keys ...termbox.Key) *ScriptEvents {
+	for _, key := range keys {
+		src.Add(termbox.Event{Type: termbox.EventKey, Key: key})
+	}
+	return src
+}
+
+// Returns number of events not polled yet.
+func (src *ScriptEvents) Len() int {
+	src.mutex.Lock()
+	defer src.mutex.Unlock()
+	return len(src.events)
+}
+
+func (src *ScriptEvents) PollEvent() termbox.Event {
+	src.mutex.Lock()
+	defer src.mutex.Unlock()
+	if src.interrupted {
+		src.interrupted = false
+		return termbox.Event{Type: termbox.EventInterrupt}
+	}
+	if len(src.events) == 0 {
+		return termbox.Event{Type: termbox.EventError, Err: io.EOF}
+	}
+	ev := src.events[0]
+	src.events = src.events[1:]
+	return ev
+}
+
+func (src *ScriptEvents) Interrupt() {
+	src.mutex.Lock()
+	defer src.mutex.Unlock()
+	src.interrupted = true
+}
@@ -0,0 +1,59 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestScriptEvents(t *testing.T) {
+	src := NewScriptEvents().Type("ab").Press(termbox.KeyEnter)
+	assert.Equal(t, src.Len(), 3)
+	assert.Equal(t, src.PollEvent(), termbox.Event{Type: termbox.EventKey, Ch: 'a'})
+	assert.Equal(t, src.PollEvent(), termbox.Event{Type: termbox.EventKey, Ch: 'b'})
+	src.Interrupt()
+	assert.Equal(t, src.PollEvent().Type, termbox.EventInterrupt)
+	assert.Equal(t, src.PollEvent(),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter})
+	assert.Equal(t, src.Len(), 0)
+	ev := src.PollEvent()
+	assert.Equal(t, ev.Type, termbox.EventError)
+	assert.Equal(t, ev.Err, io.EOF)
+}
+
+func TestChanEvents(t *testing.T) {
+	ch := make(chan termbox.Event, 1)
+	src := NewChanEvents(ch)
+	ch <- termbox.Event{Type: termbox.EventKey, Ch: 'a'}
+	assert.Equal(t, src.PollEvent(), termbox.Event{Type: termbox.EventKey, Ch: 'a'})
+
+	// Interrupt unblocks waiting PollEvent
+	polled := make(chan termbox.Event)
+	go func() {
+		polled <- src.PollEvent()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	src.Interrupt()
+	assert.Equal(t, (<-polled).Type, termbox.EventInterrupt)
+
+	// Interrupt before PollEvent wins over pending events
+	ch <- termbox.Event{Type: termbox.EventKey, Ch: 'b'}
+	src.Interrupt()
+	src.Interrupt() // Does not block
+	assert.Equal(t, src.PollEvent().Type, termbox.EventInterrupt)
+	assert.Equal(t, src.PollEvent(), termbox.Event{Type: termbox.EventKey, Ch: 'b'})
+
+	close(ch)
+	ev := src.PollEvent()
+	assert.Equal(t, ev.Type, termbox.EventError)
+	assert.Equal(t, ev.Err, io.EOF)
+}
+
+func TestSetEventSource(t *testing.T) {
+	defer SetEventSource(GetEventSource())
+	src := NewScriptEvents()
+	SetEventSource(src)
+	assert.Equal(t, GetEventSource(), EventSource(src))
+}
@@ -0,0 +1,262 @@
+package editbox
+
+import (
+	"context"
+	"github.com/nsf/termbox-go"
+)
+
+// Shift+Tab. termbox cannot report it, backends which can, like
+// tcellscreen, report it with this code.
+//
+// termbox numbers its special keys down from KeyF1 (0xFFFF) to
+// MouseWheelDown (0xFFFF - 28) and control keys are below 0x80.
+// KeyBacktab is taken below termbox keys with room for new ones,
+// so it does not collide with any termbox key.
+const KeyBacktab termbox.Key = 0xFFFF - 64
+
+// Container for widgets which moves focus between them.
+// Tab focuses next widget, Ctrl+O or KeyBacktab previous one. termbox
+// does not report Shift+Tab, so on termbox only Ctrl+O works.
+// Enter submits form unless focused widget uses Enter itself
+// like Textarea does. Esc cancels form.
+type Form struct {
+	items      []Widget
+	names      []string
+	focus      int
+	nextKeys   []termbox.Key
+	prevKeys   []termbox.Key
+	submitKeys []termbox.Key
+	cancelKeys []termbox.Key
+	layout     Layout
+}
+
+func NewForm() *Form {
+	return &Form{
+		focus:      -1,
+		nextKeys:   []termbox.Key{termbox.KeyTab},
+		prevKeys:   []termbox.Key{termbox.KeyCtrlO, KeyBacktab},
+		submitKeys: []termbox.Key{termbox.KeyEnter},
+		cancelKeys: []termbox.Key{termbox.KeyEsc},
+	}
+}
+
+// Adds widget. Its text will be returned by Values under name
+// unless name is empty. First focusable widget gets focus.
+func (form *Form) AddWidget(name string, w Widget) {
+	form.items = append(form.items, w)
+	form.names = append(form.names, name)
+	if form.focus < 0 && w.Focusable() {
+		form.focus = len(form.items) - 1
+		w.Focus()
+	} else {
+		w.Blur()
+	}
+}
+
+// Adds Input or Textarea. Its text will be returned by Values
+// under name.
+func (form *Form) AddEditbox(name string, ebox *Editbox) {
+	form.AddWidget(name, ebox)
+}
+
+// Adds Select. Its selected item will be returned by Values under name.
+func (form *Form) AddSelect(name string, sbox *SelectBox) {
+	form.AddWidget(name, sbox)
+}
+
+// Adds Label. See Label function.
+func (form *Form) AddLabel(
+	x, y, width int,
+	fg, bg termbox.Attribute,
+	text string,
+) *LabelBox {
+	lbox := NewLabel(x, y, width, fg, bg, text)
+	form.AddWidget("", lbox)
+	return lbox
+}
+
+// Sets keys which move focus to the next and previous widget.
+func (form *Form) SetFocusKeys(next, prev []termbox.Key) {
+	form.nextKeys = next
+	form.prevKeys = prev
+}
+
+// Sets keys which submit form.
+func (form *Form) SetSubmitKeys(keys ...termbox.Key) {
+	form.submitKeys = keys
+}
+
+// Sets keys which cancel form.
+func (form *Form) SetCancelKeys(keys ...termbox.Key) {
+	form.cancelKeys = keys
+}
+
+// Sets layout which positions form widgets. Layout is placed over
+// the whole terminal by Run and again on every terminal resize.
+func (form *Form) SetLayout(layout Layout) {
+	form.layout = layout
+}
+
+// Places layout over area of width and height
+func (form *Form) arrange(width, height int) {
+	if form.layout != nil {
+		form.layout.Place(0, 0, width, height)
+	}
+}
+
+// Focuses widget added under name.
+func (form *Form) Focus(name string) {
+	for i, n := range form.names {
+		if n == name && form.items[i].Focusable() {
+			form.setFocus(i)
+			return
+		}
+	}
+}
+
+func (form *Form) setFocus(i int) {
+	if form.focus >= 0 {
+		form.items[form.focus].Blur()
+	}
+	form.focus = i
+	form.items[i].Focus()
+}
+
+// Returns name of focused widget.
+func (form *Form) Focused() string {
+	if form.focus < 0 {
+		return ""
+	}
+	return form.names[form.focus]
+}
+
+// Moves focus to the next (dir = +1) or previous (dir = -1)
+// focusable widget
+func (form *Form) moveFocus(dir int) {
+	n := len(form.items)
+	for i := 1; i <= n; i++ {
+		next := ((form.focus+dir*i)%n + n) % n
+		if form.items[next].Focusable() {
+			form.setFocus(next)
+			return
+		}
+	}
+}
+
+// Returns values of all named widgets.
+func (form *Form) Values() map[string]string {
+	values := make(map[string]string)
+	for i, item := range form.items {
+		if form.names[i] != "" {
+			values[form.names[i]] = item.Text()
+		}
+	}
+	return values
+}
+
+// Puts all widgets into screen cell buffer. Cursor is shown only
+// in focused widget. This function DOES NOT flush screen.
+func (form *Form) Render() {
+	if form.layout != nil {
+		form.layout.Render()
+	}
+	hideCursor()
+	for _, item := range form.items {
+		item.Render()
+	}
+}
+
+func hasKey(keys []termbox.Key, key termbox.Key) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if screen coordinates x, y are inside widget
+func contains(w Widget, x, y int) bool {
+	wx, wy, width, height := w.Bounds()
+	return x >= wx && x < wx+width && y >= wy && y < wy+height
+}
+
+// Processes termbox event. Returns true if event finishes form
+// and whether form was submitted. Submit keys submit form only if
+// focused widget does not use them itself like Textarea uses Enter.
+// Form without focusable widgets is finished by submit and cancel keys
+// only. Error events are ignored.
+func (form *Form) HandleEvent(ev termbox.Event) (done, submitted bool) {
+	// Runes come with zero key
+	isKey := ev.Type == termbox.EventKey && ev.Ch == 0 &&
+		ev.Mod&termbox.ModAlt == 0
+	switch {
+	case isKey && hasKey(form.nextKeys, ev.Key):
+		form.moveFocus(+1)
+		return false, false
+	case isKey && hasKey(form.prevKeys, ev.Key):
+		form.moveFocus(-1)
+		return false, false
+	case isKey && hasKey(form.cancelKeys, ev.Key):
+		return true, false
+	case ev.Type == termbox.EventMouse && ev.Key == termbox.MouseLeft &&
+		ev.Mod&termbox.ModMotion == 0:
+		for i, item := range form.items {
+			if item.Focusable() && contains(item, ev.MouseX, ev.MouseY) {
+				form.setFocus(i)
+				break
+			}
+		}
+	case ev.Type == termbox.EventResize:
+		for _, item := range form.items {
+			item.HandleEvent(ev)
+		}
+		form.arrange(ev.Width, ev.Height)
+		screen.Clear(termbox.ColorDefault, termbox.ColorDefault)
+		return false, false
+	case ev.Type == termbox.EventError:
+		return false, false
+	}
+	consumed := form.focus >= 0 && form.items[form.focus].HandleEvent(ev)
+	if !consumed && isKey && hasKey(form.submitKeys, ev.Key) {
+		return true, true
+	}
+	return false, false
+}
+
+// Adds text of every Editbox with history to its history.
+// Run does it on submit.
+func (form *Form) SaveHistory() error {
+	for _, item := range form.items {
+		if ebox, ok := item.(*Editbox); ok {
+			if err := ebox.SaveHistory(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Runs event loop until form is submitted or cancelled.
+// Returns true if form was submitted and event which finished form.
+// Returns *TerminalError on termbox error event. Returns error of
+// History.Add as is if submitted values cannot be saved to history.
+func (form *Form) Run() (bool, termbox.Event, error) {
+	return form.RunContext(context.Background())
+}
+
+// Same as Run but also returns ctx.Err() when ctx is cancelled
+// or its deadline is exceeded, e.g. on idle timeout.
+func (form *Form) RunContext(ctx context.Context) (bool, termbox.Event, error) {
+	form.arrange(screen.Size())
+	submitted := false
+	ev, err := runLoop(ctx, func(ev termbox.Event) bool {
+		var done bool
+		done, submitted = form.HandleEvent(ev)
+		return done
+	}, form.Render)
+	if err != nil || !submitted {
+		return false, ev, err
+	}
+	return true, ev, form.SaveHistory()
+}
@@ -0,0 +1,128 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+func newTestInput(x, y, width int) *Editbox {
+	eb := newEditbox(x, y, width, 1, options{
+		exitKeys: []termbox.Key{
+			termbox.KeyEsc, termbox.KeyTab, termbox.KeyEnter,
+		},
+	})
+	eb.updateLineOffsets()
+	return eb
+}
+
+func newTestTextarea(x, y, width, height int) *Editbox {
+	eb := newEditbox(x, y, width, height, options{
+		exitKeys: []termbox.Key{termbox.KeyEsc, termbox.KeyTab},
+	})
+	eb.updateLineOffsets()
+	return eb
+}
+
+func newTestForm() *Form {
+	form := NewForm()
+	form.AddLabel(0, 0, 0, 0, 0, "Name:")
+	form.AddEditbox("name", newTestInput(6, 0, 10))
+	form.AddEditbox("notes", newTestTextarea(6, 1, 10, 3))
+	form.AddSelect("color", Select(6, 4, 10, 2, 0, 0, 0, 0,
+		[]string{"red", "green", "blue"}))
+	return form
+}
+
+func formKey(form *Form, key termbox.Key) (bool, bool) {
+	return form.HandleEvent(termbox.Event{Type: termbox.EventKey, Key: key})
+}
+
+func formString(form *Form, s string) {
+	for _, r := range s {
+		form.HandleEvent(termbox.Event{Type: termbox.EventKey, Ch: r})
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+func TestFormFocus(t *testing.T) {
+	form := newTestForm()
+	assert.Equal(t, form.Focused(), "name")
+	formKey(form, termbox.KeyTab)
+	assert.Equal(t, form.Focused(), "notes")
+	formKey(form, termbox.KeyTab)
+	assert.Equal(t, form.Focused(), "color")
+	formKey(form, termbox.KeyTab) // Label is skipped
+	assert.Equal(t, form.Focused(), "name")
+	formKey(form, termbox.KeyCtrlO)
+	assert.Equal(t, form.Focused(), "color")
+	formKey(form, KeyBacktab)
+	assert.Equal(t, form.Focused(), "notes")
+	form.Focus("notes")
+	assert.Equal(t, form.Focused(), "notes")
+
+	form.HandleEvent(termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 7, MouseY: 5,
+	})
+	assert.Equal(t, form.Focused(), "color")
+	form.HandleEvent(termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 0, MouseY: 0,
+	})
+	assert.Equal(t, form.Focused(), "color")
+}
+
+func TestFormSubmit(t *testing.T) {
+	form := newTestForm()
+	formString(form, "foo")
+	formKey(form, termbox.KeyTab)
+	formString(form, "bar")
+	done, _ := formKey(form, termbox.KeyEnter) // Textarea takes Enter
+	assert.False(t, done)
+	formString(form, "baz")
+	formKey(form, termbox.KeyTab)
+	formKey(form, termbox.KeyArrowDown)
+	formKey(form, termbox.KeyArrowDown)
+	done, submitted := formKey(form, termbox.KeyEnter)
+	assert.True(t, done)
+	assert.True(t, submitted)
+	assert.Equal(t, form.Values(), map[string]string{
+		"name":  "foo",
+		"notes": "bar\nbaz",
+		"color": "blue",
+	})
+
+	done, submitted = formKey(form, termbox.KeyEsc)
+	assert.True(t, done)
+	assert.False(t, submitted)
+}
+
+func TestFormWithoutFocusableWidgets(t *testing.T) {
+	form := NewForm()
+	form.AddLabel(0, 0, 0, 0, 0, "Done")
+	assert.Equal(t, form.Focused(), "")
+	for _, key := range []termbox.Key{
+		termbox.KeyTab, termbox.KeyCtrlO, termbox.KeyArrowDown, termbox.KeyF1,
+	} {
+		done, _ := formKey(form, key)
+		assert.False(t, done)
+	}
+	done, _ := form.HandleEvent(termbox.Event{Type: termbox.EventKey, Ch: 'q'})
+	assert.False(t, done)
+	done, submitted := formKey(form, termbox.KeyEnter)
+	assert.True(t, done)
+	assert.True(t, submitted)
+	done, submitted = formKey(form, termbox.KeyEsc)
+	assert.True(t, done)
+	assert.False(t, submitted)
+}
+
+func TestKeyBacktab(t *testing.T) {
+	// Below the lowest termbox special key and above control keys
+	assert.True(t, KeyBacktab < termbox.MouseWheelDown)
+	assert.True(t, KeyBacktab > termbox.KeyCtrl8)
+}
@@ -0,0 +1,258 @@
+package editbox
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// History stores values submitted in Input widgets.
+// Entries are ordered from the oldest to the newest.
+type History interface {
+	// Add value to the end of history
+	Add(value string) error
+	// Returns all history entries, the oldest first
+	Entries() []string
+}
+
+// In-memory History. Keeps at most limit entries if limit > 0.
+// Empty values and values equal to the last entry are not added.
+type MemoryHistory struct {
+	entries []string
+	limit   int
+}
+
+func NewMemoryHistory(limit int) *MemoryHistory {
+	return &MemoryHistory{limit: limit}
+}
+
+func (h *MemoryHistory) Add(value string) error {
+	h.add(value)
+	return nil
+}
+
+// Returns false if value is not added
+func (h *MemoryHistory) add(value string) bool {
+	if value == "" {
+		return false
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == value {
+		return false
+	}
+	h.entries = append(h.entries, value)
+	if h.limit > 0 && len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+	return true
+}
+
+// Returns copy of entries
+func (h *MemoryHistory) Entries() []string {
+	return append([]string(nil), h.entries...)
+}
+
+// History persisted in a text file, one entry per line.
+// Line breaks and backslashes of entries are escaped as \n and \\.
+// Whole file is rewritten on every Add which changes history. It is
+// written to a temporary file first, then renamed over the history file
+// which gets 0600 permissions.
+type FileHistory struct {
+	MemoryHistory
+	path string
+}
+
+// Loads history from file at path. Missing file is not an error,
+// it will be created on first Add.
+func NewFileHistory(path string, limit int) (*FileHistory, error) {
+	h := &FileHistory{path: path}
+	h.limit = limit
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h.MemoryHistory.Add(unescapeEntry(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+var entryEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// Reverts entryEscaper. Unknown escapes are kept as is.
+func unescapeEntry(s string) string {
+	if !strings.ContainsRune(s, '\\') {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func (h *FileHistory) Add(value string) error {
+	if !h.add(value) {
+		return nil
+	}
+	// Temporary file is renamed over history file, so interrupted
+	// write does not lose old entries
+	f, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, entry := range h.entries {
+		entryEscaper.WriteString(w, entry)
+		w.WriteByte('\n')
+	}
+	err = w.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), h.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+//----------------------------------------------------------------------------
+// History navigation in Editbox
+//----------------------------------------------------------------------------
+
+type historyBrowser struct {
+	history History
+	// Index of recalled entry. Equals to number of entries
+	// when user edits own text (draft)
+	index int
+	draft string
+	// Prefix filter for Ctrl+R search
+	prefix    string
+	searching bool
+	browsing  bool
+}
+
+func (hb *historyBrowser) reset() {
+	hb.browsing = false
+	hb.searching = false
+	hb.prefix = ""
+}
+
+func (hb *historyBrowser) start(text string) {
+	if hb.browsing {
+		return
+	}
+	hb.browsing = true
+	hb.draft = text
+	hb.index = len(hb.history.Entries())
+}
+
+func (hb *historyBrowser) matches(entry string) bool {
+	return !hb.searching || strings.HasPrefix(entry, hb.prefix)
+}
+
+// Returns previous entry matching prefix or false if there are no more
+func (hb *historyBrowser) prev() (string, bool) {
+	entries := hb.history.Entries()
+	for i := hb.index - 1; i >= 0; i-- {
+		if hb.matches(entries[i]) {
+			hb.index = i
+			return entries[i], true
+		}
+	}
+	return "", false
+}
+
+// Returns next entry matching prefix or draft
+// if there are no more entries
+func (hb *historyBrowser) next() string {
+	entries := hb.history.Entries()
+	for i := hb.index + 1; i < len(entries); i++ {
+		if hb.matches(entries[i]) {
+			hb.index = i
+			return entries[i]
+		}
+	}
+	draft := hb.draft
+	hb.reset()
+	return draft
+}
+
+func (ebox *Editbox) historyPrev() {
+	hb := ebox.history
+	hb.start(ebox.Text())
+	if s, ok := hb.prev(); ok {
+		ebox.replaceText(s)
+	}
+}
+
+func (ebox *Editbox) historyNext() {
+	hb := ebox.history
+	if !hb.browsing {
+		return
+	}
+	ebox.replaceText(hb.next())
+}
+
+func (ebox *Editbox) historySearch() {
+	hb := ebox.history
+	if !hb.searching {
+		text := ebox.Text()
+		hb.reset()
+		hb.start(text)
+		hb.searching = true
+		hb.prefix = text
+	}
+	if s, ok := hb.prev(); ok {
+		ebox.replaceText(s)
+	}
+}
+
+func (ebox *Editbox) replaceText(s string) {
+	ebox.editor.clear()
+	ebox.editor.setText(s)
+}
+
+// Enables history for single line Input widget.
+// Up/Down keys recall previous values, Ctrl+R searches history backwards
+// for values starting with the text typed so far.
+// WaitExit adds text to history when widget exits with Enter.
+func (ebox *Editbox) SetHistory(h History) {
+	if h == nil {
+		ebox.history = nil
+		return
+	}
+	ebox.history = &historyBrowser{history: h}
+}
+
+// Adds widget content to history if history is enabled.
+func (ebox *Editbox) SaveHistory() error {
+	if ebox.history == nil {
+		return nil
+	}
+	ebox.history.reset()
+	return ebox.history.history.Add(ebox.Text())
+}
@@ -0,0 +1,141 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+func newHistoryInput(entries ...string) *Editbox {
+	eb := newEditbox(0, 0, 10, 1, options{})
+	h := NewMemoryHistory(0)
+	for _, e := range entries {
+		h.Add(e)
+	}
+	eb.SetHistory(h)
+	eb.updateLineOffsets()
+	return eb
+}
+
+func sendKey(eb *Editbox, key termbox.Key) {
+	eb.HandleEvent(termbox.Event{Type: termbox.EventKey, Key: key})
+	eb.updateLineOffsets()
+}
+
+func sendString(eb *Editbox, s string) {
+	for _, r := range s {
+		eb.HandleEvent(termbox.Event{Type: termbox.EventKey, Ch: r})
+	}
+	eb.updateLineOffsets()
+}
+
+// ----------------------------------------------------------------------------
+
+func TestMemoryHistoryAdd(t *testing.T) {
+	h := NewMemoryHistory(3)
+	h.Add("foo")
+	h.Add("")
+	h.Add("bar")
+	h.Add("bar")
+	assert.Equal(t, h.Entries(), []string{"foo", "bar"})
+	h.Add("baz")
+	h.Add("qux")
+	assert.Equal(t, h.Entries(), []string{"bar", "baz", "qux"})
+	h.Entries()[0] = "changed"
+	assert.Equal(t, h.Entries(), []string{"bar", "baz", "qux"})
+}
+
+func TestFileHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h, err := NewFileHistory(path, 2)
+	assert.Nil(t, err)
+	assert.Empty(t, h.Entries())
+	assert.Nil(t, h.Add("foo"))
+	assert.Nil(t, h.Add("bar"))
+	assert.Nil(t, h.Add("baz"))
+
+	h, err = NewFileHistory(path, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, h.Entries(), []string{"bar", "baz"})
+
+	assert.Nil(t, h.Add("a\nb"))
+	assert.Nil(t, h.Add(`c:\new\x`))
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, string(data), "bar\nbaz\na\\nb\nc:\\\\new\\\\x\n")
+	h, err = NewFileHistory(path, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, h.Entries(), []string{"bar", "baz", "a\nb", `c:\new\x`})
+	assert.Equal(t, unescapeEntry(`a\tb\`), `a\tb\`)
+}
+
+func TestFileHistoryWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history")
+	h, _ := NewFileHistory(path, 0)
+	assert.Nil(t, h.Add("foo"))
+	// Skipped values do not rewrite file
+	os.Remove(path)
+	assert.Nil(t, h.Add("foo"))
+	assert.Nil(t, h.Add(""))
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	// Temporary file is not left behind
+	assert.Nil(t, h.Add("bar"))
+	files, _ := os.ReadDir(dir)
+	assert.Equal(t, len(files), 1)
+
+	h, _ = NewFileHistory(filepath.Join(dir, "missing", "history"), 0)
+	assert.NotNil(t, h.Add("foo"))
+}
+
+func TestHistoryUpDown(t *testing.T) {
+	eb := newHistoryInput("foo", "bar", "baz")
+	sendString(eb, "qu")
+	sendKey(eb, termbox.KeyArrowUp)
+	assert.Equal(t, eb.Text(), "baz")
+	sendKey(eb, termbox.KeyArrowUp)
+	sendKey(eb, termbox.KeyArrowUp)
+	assert.Equal(t, eb.Text(), "foo")
+	sendKey(eb, termbox.KeyArrowUp) // No effect
+	assert.Equal(t, eb.Text(), "foo")
+	sendKey(eb, termbox.KeyArrowDown)
+	assert.Equal(t, eb.Text(), "bar")
+	sendKey(eb, termbox.KeyArrowDown)
+	sendKey(eb, termbox.KeyArrowDown)
+	assert.Equal(t, eb.Text(), "qu")
+	assert.Equal(t, eb.editor.cursor.x, 2)
+}
+
+func TestHistorySearch(t *testing.T) {
+	eb := newHistoryInput("foo", "bar", "far", "baz")
+	sendString(eb, "f")
+	sendKey(eb, termbox.KeyCtrlR)
+	assert.Equal(t, eb.Text(), "far")
+	sendKey(eb, termbox.KeyCtrlR)
+	assert.Equal(t, eb.Text(), "foo")
+	sendKey(eb, termbox.KeyCtrlR) // No more matches
+	assert.Equal(t, eb.Text(), "foo")
+	sendKey(eb, termbox.KeyArrowDown)
+	assert.Equal(t, eb.Text(), "far")
+	sendKey(eb, termbox.KeyArrowDown)
+	assert.Equal(t, eb.Text(), "f")
+}
+
+func TestHistoryEditResetsBrowsing(t *testing.T) {
+	eb := newHistoryInput("foo", "bar")
+	sendKey(eb, termbox.KeyArrowUp)
+	sendString(eb, "!")
+	assert.Equal(t, eb.Text(), "bar!")
+	sendKey(eb, termbox.KeyArrowUp)
+	assert.Equal(t, eb.Text(), "bar")
+	sendKey(eb, termbox.KeyArrowDown)
+	assert.Equal(t, eb.Text(), "bar!")
+	assert.Nil(t, eb.SaveHistory())
+	assert.Equal(t, eb.history.history.Entries(), []string{"foo", "bar", "bar!"})
+}
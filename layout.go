@@ -0,0 +1,599 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"strings"
+)
+
+// Widget which can be positioned by layout.
+// All widgets implement it.
+type Sizer interface {
+	Bounds() (x, y, width, height int)
+	SetPosition(x, y int)
+	SetSize(width, height int)
+}
+
+// Node of layout tree. Layout computes positions and sizes of widgets
+// so they do not have to be placed with absolute coordinates.
+type Layout interface {
+	// Returns minimal size of layout
+	Size() (width, height int)
+	// Positions and resizes widgets to fit into area
+	Place(x, y, width, height int)
+	// Outputs labels owned by layout. Widgets are not rendered.
+	// This DOES NOT flush screen.
+	Render()
+}
+
+// Layout which may want extra space. Custom layouts which do not
+// implement it are kept at their minimal size.
+type Filler interface {
+	// Returns true if layout wants extra horizontal/vertical space
+	Fills() (x, y bool)
+}
+
+func fills(l Layout) (x, y bool) {
+	if f, ok := l.(Filler); ok {
+		return f.Fills()
+	}
+	return false, false
+}
+
+// Splits extra space among n parts. First parts get remainder.
+func distribute(extra, n int) []int {
+	parts := make([]int, n)
+	if n == 0 || extra <= 0 {
+		return parts
+	}
+	for i := range parts {
+		parts[i] = extra / n
+		if i < extra%n {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+func runeWidth(s string) int {
+	return len([]rune(s))
+}
+
+//----------------------------------------------------------------------------
+// Item
+//----------------------------------------------------------------------------
+
+// Layout of single widget
+type ItemLayout struct {
+	widget        Sizer
+	width, height int
+	fillX, fillY  bool
+}
+
+// Creates layout of widget. Widget current size is its minimal size.
+func Item(widget Sizer) *ItemLayout {
+	_, _, width, height := widget.Bounds()
+	return &ItemLayout{widget: widget, width: width, height: height}
+}
+
+// Makes widget take all available horizontal/vertical space.
+func (l *ItemLayout) Fill(x, y bool) *ItemLayout {
+	l.fillX = x
+	l.fillY = y
+	return l
+}
+
+func (l *ItemLayout) Size() (width, height int) {
+	return l.width, l.height
+}
+
+func (l *ItemLayout) Place(x, y, width, height int) {
+	if !l.fillX && width > l.width {
+		width = l.width
+	}
+	if !l.fillY && height > l.height {
+		height = l.height
+	}
+	l.widget.SetPosition(x, y)
+	l.widget.SetSize(width, height)
+}
+
+func (l *ItemLayout) Render() {}
+
+func (l *ItemLayout) Fills() (x, y bool) {
+	return l.fillX, l.fillY
+}
+
+//----------------------------------------------------------------------------
+// Static text
+//----------------------------------------------------------------------------
+
+// Layout of static text rendered with Text
+type StaticLayout struct {
+	x, y, width, height int
+	fg, bg              termbox.Attribute
+	text                string
+}
+
+// Creates layout of static text.
+func Static(fg, bg termbox.Attribute, text string) *StaticLayout {
+	return &StaticLayout{fg: fg, bg: bg, text: text}
+}
+
+func (l *StaticLayout) Size() (width, height int) {
+	lines := strings.Split(l.text, "\n")
+	for _, s := range lines {
+		if w := runeWidth(s); w > width {
+			width = w
+		}
+	}
+	return width, len(lines)
+}
+
+func (l *StaticLayout) Place(x, y, width, height int) {
+	l.x, l.y = x, y
+	l.width, l.height = l.Size()
+	if l.width > width {
+		l.width = width
+	}
+	if l.height > height {
+		l.height = height
+	}
+}
+
+func (l *StaticLayout) Render() {
+	if l.width > 0 && l.height > 0 {
+		Text(l.x, l.y, l.width, l.height, l.fg, l.bg, l.text)
+	}
+}
+
+func (l *StaticLayout) Fills() (x, y bool) {
+	return false, false
+}
+
+//----------------------------------------------------------------------------
+// Stacks
+//----------------------------------------------------------------------------
+
+// Layout which puts children one after another vertically or horizontally
+type StackLayout struct {
+	children   []Layout
+	horizontal bool
+	spacing    int
+}
+
+// Creates stack of children placed top to bottom.
+func VStack(children ...Layout) *StackLayout {
+	return &StackLayout{children: children}
+}
+
+// Creates stack of children placed left to right.
+// Children are separated by one column.
+func HStack(children ...Layout) *StackLayout {
+	return &StackLayout{children: children, horizontal: true, spacing: 1}
+}
+
+// Sets space between children.
+func (l *StackLayout) Spacing(spacing int) *StackLayout {
+	l.spacing = spacing
+	return l
+}
+
+// Adds children to the end of stack.
+func (l *StackLayout) Add(children ...Layout) *StackLayout {
+	l.children = append(l.children, children...)
+	return l
+}
+
+// Returns size along stack direction and across it
+func (l *StackLayout) axes(width, height int) (int, int) {
+	if l.horizontal {
+		return width, height
+	}
+	return height, width
+}
+
+func (l *StackLayout) Size() (width, height int) {
+	along, across := 0, 0
+	for i, child := range l.children {
+		a, c := l.axes(child.Size())
+		if i > 0 {
+			along += l.spacing
+		}
+		along += a
+		if c > across {
+			across = c
+		}
+	}
+	if l.horizontal {
+		return along, across
+	}
+	return across, along
+}
+
+func (l *StackLayout) Place(x, y, width, height int) {
+	total, across := l.axes(width, height)
+	min, _ := l.axes(l.Size())
+	growing := []int{}
+	for i, child := range l.children {
+		fx, fy := fills(child)
+		if l.horizontal && fx || !l.horizontal && fy {
+			growing = append(growing, i)
+		}
+	}
+	extra := distribute(total-min, len(growing))
+	pos := 0
+	for i, child := range l.children {
+		size, _ := l.axes(child.Size())
+		for j, k := range growing {
+			if k == i {
+				size += extra[j]
+			}
+		}
+		if l.horizontal {
+			child.Place(x+pos, y, size, across)
+		} else {
+			child.Place(x, y+pos, across, size)
+		}
+		pos += size + l.spacing
+	}
+}
+
+func (l *StackLayout) Render() {
+	for _, child := range l.children {
+		child.Render()
+	}
+}
+
+func (l *StackLayout) Fills() (x, y bool) {
+	for _, child := range l.children {
+		cx, cy := fills(child)
+		x = x || cx
+		y = y || cy
+	}
+	return x, y
+}
+
+//----------------------------------------------------------------------------
+// Label-field rows
+//----------------------------------------------------------------------------
+
+type fieldRow struct {
+	label  string
+	field  Layout
+	lx, ly int
+}
+
+// Layout of rows with label on the left and field on the right.
+// Labels are aligned in column as wide as the longest label.
+type FieldsLayout struct {
+	rows    []*fieldRow
+	fg, bg  termbox.Attribute
+	gap     int
+	spacing int
+	width   int
+}
+
+// Creates label-field rows layout.
+func Fields() *FieldsLayout {
+	return &FieldsLayout{gap: 1}
+}
+
+// Adds row with label and field.
+func (l *FieldsLayout) Add(label string, field Layout) *FieldsLayout {
+	l.rows = append(l.rows, &fieldRow{label: label, field: field})
+	return l
+}
+
+// Sets label colors.
+func (l *FieldsLayout) LabelColors(fg, bg termbox.Attribute) *FieldsLayout {
+	l.fg = fg
+	l.bg = bg
+	return l
+}
+
+// Sets space between label column and fields.
+func (l *FieldsLayout) Gap(gap int) *FieldsLayout {
+	l.gap = gap
+	return l
+}
+
+// Sets space between rows.
+func (l *FieldsLayout) Spacing(spacing int) *FieldsLayout {
+	l.spacing = spacing
+	return l
+}
+
+func (l *FieldsLayout) labelWidth() int {
+	width := 0
+	for _, row := range l.rows {
+		if w := runeWidth(row.label); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+func (l *FieldsLayout) Size() (width, height int) {
+	labelWidth := l.labelWidth()
+	for i, row := range l.rows {
+		w, h := row.field.Size()
+		if w > width {
+			width = w
+		}
+		if i > 0 {
+			height += l.spacing
+		}
+		height += h
+	}
+	return labelWidth + l.gap + width, height
+}
+
+func (l *FieldsLayout) Place(x, y, width, height int) {
+	l.width = l.labelWidth()
+	fieldX := x + l.width + l.gap
+	fieldWidth := width - l.width - l.gap
+	_, min := l.Size()
+	growing := []int{}
+	for i, row := range l.rows {
+		if _, fy := fills(row.field); fy {
+			growing = append(growing, i)
+		}
+	}
+	extra := distribute(height-min, len(growing))
+	for i, row := range l.rows {
+		_, h := row.field.Size()
+		for j, k := range growing {
+			if k == i {
+				h += extra[j]
+			}
+		}
+		row.lx, row.ly = x, y
+		row.field.Place(fieldX, y, fieldWidth, h)
+		y += h + l.spacing
+	}
+}
+
+func (l *FieldsLayout) Render() {
+	for _, row := range l.rows {
+		Label(row.lx, row.ly, l.width, l.fg, l.bg, row.label)
+		row.field.Render()
+	}
+}
+
+func (l *FieldsLayout) Fills() (x, y bool) {
+	for _, row := range l.rows {
+		_, fy := fills(row.field)
+		y = y || fy
+	}
+	return true, y
+}
+
+//----------------------------------------------------------------------------
+// Grid
+//----------------------------------------------------------------------------
+
+type gridCell struct {
+	layout           Layout
+	col, row         int
+	colSpan, rowSpan int
+}
+
+// Layout which puts children into cells of grid. Child may span
+// several columns and rows.
+type GridLayout struct {
+	columns          int
+	cells            []*gridCell
+	taken            map[[2]int]bool
+	colSpacing       int
+	rowSpacing       int
+	nextCol, nextRow int
+}
+
+// Creates grid with columns. Cells are separated by one column.
+func Grid(columns int) *GridLayout {
+	if columns < 1 {
+		columns = 1
+	}
+	return &GridLayout{
+		columns:    columns,
+		taken:      make(map[[2]int]bool),
+		colSpacing: 1,
+	}
+}
+
+// Sets space between columns and rows.
+func (l *GridLayout) Spacing(cols, rows int) *GridLayout {
+	l.colSpacing = cols
+	l.rowSpacing = rows
+	return l
+}
+
+func (l *GridLayout) free(col, row, colSpan, rowSpan int) bool {
+	if col+colSpan > l.columns {
+		return false
+	}
+	for r := row; r < row+rowSpan; r++ {
+		for c := col; c < col+colSpan; c++ {
+			if l.taken[[2]int{c, r}] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Adds child into the next free cell left to right, top to bottom.
+// Child spans colSpan columns and rowSpan rows.
+func (l *GridLayout) Add(child Layout, colSpan, rowSpan int) *GridLayout {
+	if colSpan < 1 {
+		colSpan = 1
+	}
+	if colSpan > l.columns {
+		colSpan = l.columns
+	}
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	col, row := l.nextCol, l.nextRow
+	for !l.free(col, row, colSpan, rowSpan) {
+		col++
+		if col >= l.columns {
+			col = 0
+			row++
+		}
+	}
+	for r := row; r < row+rowSpan; r++ {
+		for c := col; c < col+colSpan; c++ {
+			l.taken[[2]int{c, r}] = true
+		}
+	}
+	l.cells = append(l.cells, &gridCell{child, col, row, colSpan, rowSpan})
+	l.nextCol, l.nextRow = col+colSpan, row
+	return l
+}
+
+func (l *GridLayout) rows() int {
+	rows := 0
+	for _, cell := range l.cells {
+		if cell.row+cell.rowSpan > rows {
+			rows = cell.row + cell.rowSpan
+		}
+	}
+	return rows
+}
+
+// Returns minimal sizes of tracks (columns or rows) so every cell fits.
+// Spanning cells widen their last track.
+func (l *GridLayout) tracks(n, spacing int, span func(*gridCell) (int, int, int)) []int {
+	sizes := make([]int, n)
+	for pass := 0; pass < 2; pass++ {
+		for _, cell := range l.cells {
+			start, count, size := span(cell)
+			if (pass == 0) != (count == 1) {
+				continue
+			}
+			have := spacing * (count - 1)
+			for i := start; i < start+count; i++ {
+				have += sizes[i]
+			}
+			if size > have {
+				sizes[start+count-1] += size - have
+			}
+		}
+	}
+	return sizes
+}
+
+func (l *GridLayout) colWidths() []int {
+	return l.tracks(l.columns, l.colSpacing, func(c *gridCell) (int, int, int) {
+		w, _ := c.layout.Size()
+		return c.col, c.colSpan, w
+	})
+}
+
+func (l *GridLayout) rowHeights() []int {
+	return l.tracks(l.rows(), l.rowSpacing, func(c *gridCell) (int, int, int) {
+		_, h := c.layout.Size()
+		return c.row, c.rowSpan, h
+	})
+}
+
+func sumTracks(sizes []int, spacing int) int {
+	total := 0
+	for i, size := range sizes {
+		if i > 0 {
+			total += spacing
+		}
+		total += size
+	}
+	return total
+}
+
+func (l *GridLayout) Size() (width, height int) {
+	return sumTracks(l.colWidths(), l.colSpacing),
+		sumTracks(l.rowHeights(), l.rowSpacing)
+}
+
+// Returns start positions of tracks
+func trackStarts(sizes []int, spacing int) []int {
+	starts := make([]int, len(sizes))
+	pos := 0
+	for i, size := range sizes {
+		starts[i] = pos
+		pos += size + spacing
+	}
+	return starts
+}
+
+func (l *GridLayout) Place(x, y, width, height int) {
+	widths := l.colWidths()
+	heights := l.rowHeights()
+	fillX, fillY := l.Fills()
+	if fillX {
+		extra := distribute(width-sumTracks(widths, l.colSpacing), len(widths))
+		for i := range widths {
+			widths[i] += extra[i]
+		}
+	}
+	if fillY {
+		extra := distribute(height-sumTracks(heights, l.rowSpacing), len(heights))
+		for i := range heights {
+			heights[i] += extra[i]
+		}
+	}
+	xs := trackStarts(widths, l.colSpacing)
+	ys := trackStarts(heights, l.rowSpacing)
+	for _, cell := range l.cells {
+		w := sumTracks(widths[cell.col:cell.col+cell.colSpan], l.colSpacing)
+		h := sumTracks(heights[cell.row:cell.row+cell.rowSpan], l.rowSpacing)
+		cell.layout.Place(x+xs[cell.col], y+ys[cell.row], w, h)
+	}
+}
+
+func (l *GridLayout) Render() {
+	for _, cell := range l.cells {
+		cell.layout.Render()
+	}
+}
+
+func (l *GridLayout) Fills() (x, y bool) {
+	for _, cell := range l.cells {
+		cx, cy := fills(cell.layout)
+		x = x || cx
+		y = y || cy
+	}
+	return x, y
+}
+
+//----------------------------------------------------------------------------
+// Padding
+//----------------------------------------------------------------------------
+
+// Layout which adds empty space around child
+type PadLayout struct {
+	child                    Layout
+	top, right, bottom, left int
+}
+
+// Adds empty space around child.
+func Pad(child Layout, top, right, bottom, left int) *PadLayout {
+	return &PadLayout{child, top, right, bottom, left}
+}
+
+func (l *PadLayout) Size() (width, height int) {
+	width, height = l.child.Size()
+	return width + l.left + l.right, height + l.top + l.bottom
+}
+
+func (l *PadLayout) Place(x, y, width, height int) {
+	l.child.Place(x+l.left, y+l.top,
+		width-l.left-l.right, height-l.top-l.bottom)
+}
+
+func (l *PadLayout) Render() {
+	l.child.Render()
+}
+
+func (l *PadLayout) Fills() (x, y bool) {
+	return fills(l.child)
+}
@@ -0,0 +1,123 @@
+package editbox
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func bounds(w Sizer) [4]int {
+	x, y, width, height := w.Bounds()
+	return [4]int{x, y, width, height}
+}
+
+func TestDistribute(t *testing.T) {
+	assert.Equal(t, distribute(7, 3), []int{3, 2, 2})
+	assert.Equal(t, distribute(-1, 2), []int{0, 0})
+	assert.Equal(t, distribute(5, 0), []int{})
+}
+
+func TestFieldsLayout(t *testing.T) {
+	name := newTestInput(0, 0, 10)
+	notes := newTestTextarea(0, 0, 10, 2)
+	fields := Fields().
+		Add("Name:", Item(name).Fill(true, false)).
+		Add("Long label:", Item(notes).Fill(true, true))
+	w, h := fields.Size()
+	assert.Equal(t, w, 22)
+	assert.Equal(t, h, 3)
+
+	fields.Place(1, 2, 40, 10)
+	assert.Equal(t, bounds(name), [4]int{13, 2, 28, 1})
+	assert.Equal(t, bounds(notes), [4]int{13, 3, 28, 9})
+	assert.Equal(t, fields.rows[1].lx, 1)
+	assert.Equal(t, fields.rows[1].ly, 3)
+
+	// Rerun on resize
+	fields.Place(1, 2, 30, 5)
+	assert.Equal(t, bounds(name), [4]int{13, 2, 18, 1})
+	assert.Equal(t, bounds(notes), [4]int{13, 3, 18, 4})
+}
+
+func TestStackLayout(t *testing.T) {
+	a := newTestInput(0, 0, 5)
+	b := newTestInput(0, 0, 5)
+	c := newTestTextarea(0, 0, 5, 2)
+	stack := VStack(Item(a), Item(b).Fill(true, false), Item(c).Fill(true, true)).
+		Spacing(1)
+	w, h := stack.Size()
+	assert.Equal(t, w, 5)
+	assert.Equal(t, h, 6)
+	stack.Place(0, 0, 20, 10)
+	assert.Equal(t, bounds(a), [4]int{0, 0, 5, 1})
+	assert.Equal(t, bounds(b), [4]int{0, 2, 20, 1})
+	assert.Equal(t, bounds(c), [4]int{0, 4, 20, 6})
+
+	a = newTestInput(0, 0, 5)
+	b = newTestInput(0, 0, 5)
+	row := HStack(Static(0, 0, "Find:"), Item(a).Fill(true, false), Item(b))
+	w, h = row.Size()
+	assert.Equal(t, w, 17)
+	assert.Equal(t, h, 1)
+	row.Place(0, 0, 30, 1)
+	assert.Equal(t, bounds(a), [4]int{6, 0, 18, 1})
+	assert.Equal(t, bounds(b), [4]int{25, 0, 5, 1})
+}
+
+func TestGridLayout(t *testing.T) {
+	a := newTestInput(0, 0, 4)
+	b := newTestInput(0, 0, 6)
+	c := newTestInput(0, 0, 15)
+	d := newTestTextarea(0, 0, 3, 3)
+	e := newTestInput(0, 0, 2)
+	grid := Grid(3).
+		Add(Item(a), 1, 1).
+		Add(Item(b), 1, 1).
+		Add(Item(d), 1, 2).
+		Add(Item(c), 2, 1).
+		Add(Item(e), 1, 1)
+	w, h := grid.Size()
+	// c spans columns 4 and 6 + spacing, widens second column to 10
+	assert.Equal(t, w, 4+1+10+1+3)
+	// d spans two rows and makes second row two lines high
+	assert.Equal(t, h, 4)
+	grid.Place(1, 1, 50, 10)
+	assert.Equal(t, bounds(a), [4]int{1, 1, 4, 1})
+	assert.Equal(t, bounds(b), [4]int{6, 1, 6, 1})
+	assert.Equal(t, bounds(d), [4]int{17, 1, 3, 3})
+	assert.Equal(t, bounds(c), [4]int{1, 2, 15, 1})
+	assert.Equal(t, bounds(e), [4]int{1, 4, 2, 1})
+}
+
+func TestPadLayout(t *testing.T) {
+	a := newTestInput(0, 0, 5)
+	pad := Pad(Item(a).Fill(true, false), 1, 2, 3, 4)
+	w, h := pad.Size()
+	assert.Equal(t, w, 11)
+	assert.Equal(t, h, 5)
+	pad.Place(0, 0, 20, 20)
+	assert.Equal(t, bounds(a), [4]int{4, 1, 14, 1})
+}
+
+// Layout defined outside of package
+type fixedLayout struct {
+	x, y, width, height int
+}
+
+func (l *fixedLayout) Size() (width, height int) { return 2, 1 }
+
+func (l *fixedLayout) Place(x, y, width, height int) {
+	l.x, l.y, l.width, l.height = x, y, width, height
+}
+
+func (l *fixedLayout) Render() {}
+
+type fillingLayout struct{ fixedLayout }
+
+func (l *fillingLayout) Fills() (x, y bool) { return true, false }
+
+func TestCustomLayout(t *testing.T) {
+	a, b := &fixedLayout{}, &fillingLayout{}
+	HStack(a, b).Place(0, 0, 10, 1)
+	assert.Equal(t, [4]int{a.x, a.y, a.width, a.height}, [4]int{0, 0, 2, 1})
+	assert.Equal(t, [4]int{b.x, b.y, b.width, b.height}, [4]int{3, 0, 7, 1})
+}
@@ -4,14 +4,19 @@ type line struct {
 	text []rune
 }
 
-func (l *line) checkXPosition(x int) {
-	if x < 0 || x > len(l.text) {
-		panic("x position out of range")
+// Returns x within line bounds
+func (l *line) clampX(x int) int {
+	if x < 0 {
+		return 0
+	}
+	if x > len(l.text) {
+		return len(l.text)
 	}
+	return x
 }
 
 func (l *line) insertRune(pos int, r rune) {
-	l.checkXPosition(pos)
+	pos = l.clampX(pos)
 	// Append
 	if pos == len(l.text) {
 		l.text = append(l.text, r)
@@ -24,7 +29,7 @@ func (l *line) insertRune(pos int, r rune) {
 }
 
 func (l *line) split(pos int) (left, right *line) {
-	l.checkXPosition(pos)
+	pos = l.clampX(pos)
 	left, right = l, new(line)
 	right.text = make([]rune, len(l.text)-pos)
 	copy(right.text, l.text[pos:len(l.text)])
@@ -33,7 +38,7 @@ func (l *line) split(pos int) (left, right *line) {
 }
 
 func (l *line) deleteRune(pos int) rune {
-	l.checkXPosition(pos)
+	pos = l.clampX(pos)
 	if pos < len(l.text) {
 		r := l.text[pos]
 		copy(l.text[pos:], l.text[pos+1:])
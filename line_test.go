@@ -38,14 +38,12 @@ func TestLineInsertCornerCase1(t *testing.T) {
 }
 
 func TestLineInsertOnWrongPosition(t *testing.T) {
-	defer func() {
-		if r := recover(); r != "x position out of range" {
-			t.Errorf("Wrong panic: %+q", r)
-		}
-	}()
 	l := new(line)
 	l.text = []rune("1")
 	l.insertRune(2, '2')
+	assert.Equal(t, string(l.text), "12")
+	l.insertRune(-1, '0')
+	assert.Equal(t, string(l.text), "012")
 }
 
 func TestLineInsertNewLine(t *testing.T) {
@@ -64,25 +62,18 @@ func TestLineSplit(t *testing.T) {
 }
 
 func TestLineSplitOnWrongPosition(t *testing.T) {
-	defer func() {
-		if r := recover(); r != "x position out of range" {
-			t.Errorf("Wrong panic: %+q", r)
-		}
-	}()
 	l := new(line)
 	l.text = []rune("Sick")
-	_, _ = l.split(10)
+	left, right := l.split(10)
+	assert.Equal(t, string(left.text), "Sick")
+	assert.Equal(t, string(right.text), "")
 }
 
 func TestLineDeleteOnWrongPosition(t *testing.T) {
-	defer func() {
-		if r := recover(); r != "x position out of range" {
-			t.Errorf("Wrong panic: %+q", r)
-		}
-	}()
 	l := new(line)
 	l.text = []rune("1")
-	l.deleteRune(2)
+	assert.Equal(t, l.deleteRune(2), rune(0))
+	assert.Equal(t, string(l.text), "1")
 }
 
 func TestLineDelete(t *testing.T) {
@@ -0,0 +1,185 @@
+package editbox
+
+import (
+	"sort"
+	"strings"
+)
+
+// Returns line text without trailing line break
+func (ed *editor) lineText(y int) string {
+	l := &ed.lines[y]
+	return string(l.text[:l.lastRuneX()])
+}
+
+// Returns first and last line of selection or cursor line if there is
+// no selection. Line where selection ends at column 0 is not included.
+func (ed *editor) selectedLines() (top, bottom int) {
+	if !ed.selecting {
+		return ed.cursor.y, ed.cursor.y
+	}
+	start, end := orderedRange(ed.anchor, ed.cursor)
+	if end.x == 0 && end.y > start.y && !ed.block {
+		end.y--
+	}
+	return start.y, end.y
+}
+
+// Replaces lines from top to bottom with new ones. Line breaks between
+// lines are managed here so last line never ends with line break.
+func (ed *editor) replaceLines(top, bottom int, lines []string) {
+	start := cursor{0, top}
+	end := cursor{ed.lines[bottom].lastRuneX(), bottom}
+	ed.deleteRange(start, end)
+	ed.setText(strings.Join(lines, "\n"))
+}
+
+// Moves cursor to x, y keeping it within line
+func (ed *editor) placeCursor(x, y int) {
+	if last := ed.lines[y].lastRuneX(); x > last {
+		ed.cursor = cursor{last, y}
+	} else {
+		ed.cursor = cursor{x, y}
+	}
+	ed.lastx = x
+}
+
+func (ed *editor) duplicateLines() {
+	top, bottom := ed.selectedLines()
+	x, y, dy := ed.cursor.x, ed.cursor.y, bottom-top+1
+	lines := make([]string, 0, dy*2)
+	for i := top; i <= bottom; i++ {
+		lines = append(lines, ed.lineText(i))
+	}
+	ed.clearSelection()
+	ed.replaceLines(top, bottom, append(lines, lines...))
+	ed.placeCursor(x, y+dy)
+}
+
+func (ed *editor) deleteLines() {
+	top, bottom := ed.selectedLines()
+	x := ed.lastx
+	ed.clearSelection()
+	switch {
+	case bottom < len(ed.lines)-1:
+		ed.deleteRange(cursor{0, top}, cursor{0, bottom + 1})
+	case top > 0:
+		// Remove line break of previous line instead
+		ed.deleteRange(
+			cursor{ed.lines[top-1].lastRuneX(), top - 1},
+			cursor{len(ed.lines[bottom].text), bottom},
+		)
+		top--
+	default:
+		ed.deleteRange(cursor{0, 0}, ed.endCursor())
+	}
+	ed.placeCursor(x, top)
+}
+
+// Moves selected lines or cursor line one line up (dy = -1)
+// or down (dy = +1). Selection moves together with lines.
+func (ed *editor) moveLines(dy int) {
+	top, bottom := ed.selectedLines()
+	if top+dy < 0 || bottom+dy > len(ed.lines)-1 {
+		return
+	}
+	x := ed.cursor.x
+	lines := []string{}
+	for y := top; y <= bottom; y++ {
+		lines = append(lines, ed.lineText(y))
+	}
+	selecting, anchor, cursorY := ed.selecting, ed.anchor, ed.cursor.y
+	if dy < 0 {
+		lines = append(lines, ed.lineText(top-1))
+		ed.replaceLines(top-1, bottom, lines)
+	} else {
+		lines = append([]string{ed.lineText(bottom + 1)}, lines...)
+		ed.replaceLines(top, bottom+1, lines)
+	}
+	// Selection may end at column 0 of the line after moved lines.
+	// That end stays after them, at the end of text if it is the last line.
+	move := func(c cursor) cursor {
+		if c.y+dy > len(ed.lines)-1 {
+			return ed.endCursor()
+		}
+		return ed.clamp(Position{Line: c.y + dy, Col: c.x})
+	}
+	ed.cursor = move(cursor{x, cursorY})
+	ed.lastx = x
+	if selecting {
+		ed.selecting = true
+		ed.anchor = move(anchor)
+	}
+}
+
+// Joins cursor line with the next one separating them with single space
+func (ed *editor) joinLines() {
+	top, bottom := ed.selectedLines()
+	if bottom == top {
+		bottom++
+	}
+	if bottom > len(ed.lines)-1 {
+		return
+	}
+	ed.clearSelection()
+	joined := ed.lineText(top)
+	x := len([]rune(joined))
+	for y := top + 1; y <= bottom; y++ {
+		next := strings.TrimLeft(ed.lineText(y), " \t")
+		if joined != "" && next != "" && !strings.HasSuffix(joined, " ") {
+			joined += " "
+		}
+		x = len([]rune(joined))
+		joined += next
+	}
+	ed.replaceLines(top, bottom, []string{joined})
+	ed.placeCursor(x, top)
+}
+
+func (ed *editor) sortLines() {
+	top, bottom := ed.selectedLines()
+	lines := []string{}
+	for y := top; y <= bottom; y++ {
+		lines = append(lines, ed.lineText(y))
+	}
+	sort.Strings(lines)
+	ed.clearSelection()
+	ed.replaceLines(top, bottom, lines)
+	ed.placeCursor(0, top)
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Line commands operate on selected lines or on cursor line if there
+// is no selection. They do nothing in read-only mode.
+
+// Inserts copy of lines below them.
+func (ebox *Editbox) DuplicateLine() {
+	ebox.editCommand(ebox.editor.duplicateLines)
+}
+
+// Deletes lines.
+func (ebox *Editbox) DeleteLine() {
+	ebox.editCommand(ebox.editor.deleteLines)
+}
+
+// Swaps lines with the line above.
+func (ebox *Editbox) MoveLineUp() {
+	ebox.editCommand(func() { ebox.editor.moveLines(-1) })
+}
+
+// Swaps lines with the line below.
+func (ebox *Editbox) MoveLineDown() {
+	ebox.editCommand(func() { ebox.editor.moveLines(+1) })
+}
+
+// Joins lines into one. Without selection joins cursor line with the next.
+func (ebox *Editbox) JoinLines() {
+	ebox.editCommand(ebox.editor.joinLines)
+}
+
+// Sorts lines in ascending order.
+func (ebox *Editbox) SortLines() {
+	ebox.editCommand(ebox.editor.sortLines)
+}
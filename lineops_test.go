@@ -0,0 +1,138 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+func sendAltKey(eb *Editbox, key termbox.Key, ch rune) {
+	eb.HandleEvent(termbox.Event{
+		Type: termbox.EventKey, Mod: termbox.ModAlt, Key: key, Ch: ch,
+	})
+	eb.updateLineOffsets()
+}
+
+// ----------------------------------------------------------------------------
+
+func TestDuplicateLine(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("foo\nbar")
+	eb.SetCursor(1, 0)
+	sendAltKey(eb, 0, 'd')
+	assert.Equal(t, eb.editor.toLines(), []string{"foo\n", "foo\n", "bar"})
+	assert.Equal(t, eb.editor.cursor, cursor{1, 1})
+	eb.SetCursor(2, 2)
+	eb.DuplicateLine()
+	assert.Equal(t, eb.editor.toLines(),
+		[]string{"foo\n", "foo\n", "bar\n", "bar"})
+	assert.Equal(t, eb.editor.cursor, cursor{2, 3})
+}
+
+func TestDeleteLine(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("foo\nbar\nbaz")
+	eb.SetCursor(3, 1)
+	sendAltKey(eb, 0, 'k')
+	assert.Equal(t, eb.editor.toLines(), []string{"foo\n", "baz"})
+	assert.Equal(t, eb.editor.cursor, cursor{3, 1})
+	eb.DeleteLine()
+	assert.Equal(t, eb.editor.toLines(), []string{"foo"})
+	assert.Equal(t, eb.editor.cursor, cursor{3, 0})
+	eb.DeleteLine()
+	assert.Equal(t, eb.editor.toLines(), []string{""})
+}
+
+func TestMoveLines(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("1\n2\n3\n4")
+	eb.SetCursor(0, 3)
+	sendAltKey(eb, termbox.KeyArrowUp, 0)
+	assert.Equal(t, eb.Text(), "1\n2\n4\n3")
+	assert.Equal(t, eb.editor.cursor, cursor{0, 2})
+	sendAltKey(eb, termbox.KeyArrowDown, 0)
+	sendAltKey(eb, termbox.KeyArrowDown, 0) // No effect
+	assert.Equal(t, eb.Text(), "1\n2\n3\n4")
+
+	// Move selected lines
+	eb.SetCursor(0, 1)
+	eb.ToggleSelection()
+	eb.SetCursor(1, 2)
+	eb.MoveLineUp()
+	assert.Equal(t, eb.Text(), "2\n3\n1\n4")
+	assert.Equal(t, eb.SelectedText(), "2\n3")
+	eb.MoveLineDown()
+	eb.MoveLineDown()
+	assert.Equal(t, eb.Text(), "1\n4\n2\n3")
+}
+
+func TestMoveLinesSelectionEndsAtLineStart(t *testing.T) {
+	// Cursor ends selection at column 0 of the last line
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("a\nb\nc")
+	eb.SetCursor(0, 0)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlSpace)
+	sendKey(eb, termbox.KeyArrowDown)
+	sendKey(eb, termbox.KeyArrowDown)
+	sendAltKey(eb, termbox.KeyArrowDown, 0)
+	assert.Equal(t, eb.Text(), "c\na\nb")
+	assert.Equal(t, eb.SelectedText(), "a\nb")
+	sendAltKey(eb, termbox.KeyArrowUp, 0)
+	assert.Equal(t, eb.Text(), "a\nb\nc")
+	assert.Equal(t, eb.SelectedText(), "a\nb")
+
+	// Anchor ends selection at column 0 of the last line
+	eb = newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("a\nb\nc")
+	eb.SetCursor(0, 2)
+	eb.ToggleSelection()
+	eb.SetCursor(0, 0)
+	eb.MoveLineDown()
+	assert.Equal(t, eb.Text(), "c\na\nb")
+	assert.Equal(t, eb.SelectedText(), "a\nb")
+	eb.Copy()
+	assert.Equal(t, ClipboardText(), "a\nb")
+
+	// Moving up keeps selection end after moved lines
+	eb = newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("a\nb\nc\nd")
+	eb.SetCursor(0, 3)
+	eb.ToggleSelection()
+	eb.SetCursor(0, 1)
+	eb.MoveLineUp()
+	assert.Equal(t, eb.Text(), "b\nc\na\nd")
+	assert.Equal(t, eb.SelectedText(), "b\nc\n")
+}
+
+func TestJoinLines(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("foo\n   bar\n\nbaz")
+	eb.SetCursor(0, 0)
+	sendAltKey(eb, 0, 'j')
+	assert.Equal(t, eb.Text(), "foo bar\n\nbaz")
+	assert.Equal(t, eb.editor.cursor, cursor{4, 0})
+	eb.JoinLines()
+	eb.JoinLines()
+	assert.Equal(t, eb.Text(), "foo bar baz")
+	eb.JoinLines() // No effect
+	assert.Equal(t, eb.Text(), "foo bar baz")
+}
+
+func TestSortLines(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("c\nb\na\n0")
+	eb.SetCursor(0, 0)
+	eb.ToggleSelection()
+	eb.SetCursor(0, 3)
+	sendAltKey(eb, 0, 's')
+	assert.Equal(t, eb.Text(), "a\nb\nc\n0")
+	eb.SetReadOnly(true)
+	eb.SelectAll()
+	eb.SortLines()
+	assert.Equal(t, eb.Text(), "a\nb\nc\n0")
+}
@@ -0,0 +1,216 @@
+package editbox
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"strings"
+	"sync"
+)
+
+// Screen which keeps cells in memory and reads events from script.
+// Useful for tests and headless runs:
+//
+//	s := NewMemoryScreen(20, 1)
+//	s.Type("abc").Press(termbox.KeyEnter)
+//	SetScreen(s)
+//	input := Input(0, 0, 10, termbox.ColorWhite, termbox.ColorBlue)
+//	input.WaitExit()
+//	s.String() // "abc"
+type MemoryScreen struct {
+	*ScriptEvents
+	mutex            sync.Mutex
+	width, height    int
+	cells            []termbox.Cell
+	cursorX, cursorY int
+	flushes          int
+}
+
+// Creates screen of width x height spaces in default colors
+// with hidden cursor and empty script.
+func NewMemoryScreen(width, height int) *MemoryScreen {
+	s := &MemoryScreen{
+		ScriptEvents: NewScriptEvents(),
+		width:        width,
+		height:       height,
+		cursorX:      -1,
+		cursorY:      -1,
+	}
+	s.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	return s
+}
+
+func (s *MemoryScreen) SetCell(x, y int, ch rune, fg, bg termbox.Attribute) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return
+	}
+	s.cells[y*s.width+x] = termbox.Cell{Ch: ch, Fg: fg, Bg: bg}
+}
+
+func (s *MemoryScreen) SetCursor(x, y int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if x < 0 || y < 0 {
+		x, y = -1, -1
+	}
+	s.cursorX, s.cursorY = x, y
+}
+
+func (s *MemoryScreen) Size() (width, height int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.width, s.height
+}
+
+func (s *MemoryScreen) Clear(fg, bg termbox.Attribute) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cells = make([]termbox.Cell, s.width*s.height)
+	for i := range s.cells {
+		s.cells[i] = termbox.Cell{Ch: ' ', Fg: fg, Bg: bg}
+	}
+}
+
+// Counts flushes. Cells are visible right after SetCell.
+func (s *MemoryScreen) Flush() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.flushes++
+	return nil
+}
+
+// Returns number of Flush calls
+func (s *MemoryScreen) Flushes() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.flushes
+}
+
+// Returns cell at x, y. Cells outside of screen are empty.
+func (s *MemoryScreen) Cell(x, y int) termbox.Cell {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return termbox.Cell{}
+	}
+	return s.cells[y*s.width+x]
+}
+
+// Returns cursor position or -1, -1 if cursor is hidden
+func (s *MemoryScreen) Cursor() (x, y int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cursorX, s.cursorY
+}
+
+// Changes screen size keeping cells which fit new size.
+// This DOES NOT send resize event, add it to script if needed.
+func (s *MemoryScreen) Resize(width, height int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	cells := make([]termbox.Cell, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < s.width && y < s.height {
+				cells[y*width+x] = s.cells[y*s.width+x]
+			} else {
+				cells[y*width+x] = termbox.Cell{Ch: ' '}
+			}
+		}
+	}
+	s.width, s.height, s.cells = width, height, cells
+}
+
+// Returns screen runes line by line without trailing spaces
+// and trailing empty lines.
+func (s *MemoryScreen) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	lines := make([]string, s.height)
+	for y := range lines {
+		runes := make([]rune, s.width)
+		for x := range runes {
+			runes[x] = s.cells[y*s.width+x].Ch
+			if runes[x] == 0 {
+				runes[x] = ' '
+			}
+		}
+		lines[y] = strings.TrimRight(string(runes), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// Returns screen lines with ANSI color and attribute sequences.
+// Every line ends with reset sequence.
+func (s *MemoryScreen) ANSI() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	buf := bytes.NewBufferString("")
+	for y := 0; y < s.height; y++ {
+		var fg, bg termbox.Attribute
+		for x := 0; x < s.width; x++ {
+			cell := s.cells[y*s.width+x]
+			if x == 0 || cell.Fg != fg || cell.Bg != bg {
+				fg, bg = cell.Fg, cell.Bg
+				buf.WriteString(sgr(fg, bg))
+			}
+			if cell.Ch == 0 {
+				cell.Ch = ' '
+			}
+			buf.WriteRune(cell.Ch)
+		}
+		buf.WriteString("\x1b[0m\n")
+	}
+	return buf.String()
+}
+
+// Returns SGR sequence of termbox colors and attributes the way termbox
+// draws them: attributes are taken from fg, reverse from both.
+func sgr(fg, bg termbox.Attribute) string {
+	params := []string{"0"}
+	attrs := []struct {
+		attr  termbox.Attribute
+		param string
+	}{
+		{termbox.AttrBold, "1"},
+		{termbox.AttrDim, "2"},
+		{termbox.AttrCursive, "3"},
+		{termbox.AttrUnderline, "4"},
+		{termbox.AttrBlink, "5"},
+		{termbox.AttrHidden, "8"},
+	}
+	for _, a := range attrs {
+		if fg&a.attr != 0 {
+			params = append(params, a.param)
+		}
+	}
+	if (fg|bg)&termbox.AttrReverse != 0 {
+		params = append(params, "7")
+	}
+	if p := sgrColor(fg, 30); p != "" {
+		params = append(params, p)
+	}
+	if p := sgrColor(bg, 40); p != "" {
+		params = append(params, p)
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// Returns SGR parameter of attribute color. base is 30 for foreground
+// and 40 for background.
+func sgrColor(attr termbox.Attribute, base int) string {
+	c, rgb, r, g, b := AttributeColor(attr)
+	switch {
+	case rgb:
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+	case c == int(termbox.ColorDefault):
+		return ""
+	case c <= 8:
+		return fmt.Sprint(base + c - 1)
+	case c <= 16:
+		return fmt.Sprint(base + 60 + c - 9)
+	}
+	return fmt.Sprintf("%d;5;%d", base+8, c-1)
+}
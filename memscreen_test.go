@@ -0,0 +1,135 @@
+package editbox
+
+import (
+	"flag"
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+// Compares actual with testdata/name. Run go test -update to write
+// actual into golden file.
+func assertGolden(t *testing.T, name, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, actual, string(expected))
+}
+
+// Screen text with cursor position
+func snapshot(s *MemoryScreen) string {
+	x, y := s.Cursor()
+	return fmt.Sprintf("%s\n-- cursor %d,%d --\n", s.String(), x, y)
+}
+
+// ----------------------------------------------------------------------------
+
+func TestMemoryScreen(t *testing.T) {
+	s := NewMemoryScreen(4, 2)
+	assert.Equal(t, s.String(), "")
+	s.SetCell(1, 0, 'a', termbox.ColorRed, termbox.ColorDefault)
+	s.SetCell(4, 0, 'b', 0, 0)
+	s.SetCell(0, -1, 'c', 0, 0)
+	assert.Equal(t, s.String(), " a")
+	assert.Equal(t, s.Cell(1, 0), termbox.Cell{Ch: 'a', Fg: termbox.ColorRed})
+	assert.Equal(t, s.Cell(5, 5), termbox.Cell{})
+
+	s.SetCursor(3, 1)
+	x, y := s.Cursor()
+	assert.Equal(t, []int{x, y}, []int{3, 1})
+	s.SetCursor(-1, 0)
+	x, y = s.Cursor()
+	assert.Equal(t, []int{x, y}, []int{-1, -1})
+
+	s.Resize(2, 3)
+	s.SetCell(1, 2, 'd', 0, 0)
+	assert.Equal(t, s.String(), " a\n\n d")
+	w, h := s.Size()
+	assert.Equal(t, []int{w, h}, []int{2, 3})
+
+	s.Clear(0, 0)
+	assert.Equal(t, s.String(), "")
+}
+
+func TestMemoryScreenANSI(t *testing.T) {
+	s := NewMemoryScreen(5, 1)
+	s.SetCell(0, 0, 'a', termbox.ColorRed|termbox.AttrBold, termbox.ColorBlue)
+	s.SetCell(1, 0, 'b', termbox.ColorLightGray, termbox.Attribute(200))
+	s.SetCell(2, 0, 'c', termbox.RGBToAttribute(1, 2, 3), 0)
+	s.SetCell(3, 0, 'd', termbox.AttrUnderline, termbox.AttrReverse)
+	assert.Equal(t, s.ANSI(), "\x1b[0;1;31;44ma"+
+		"\x1b[0;97;48;5;199mb"+
+		"\x1b[0;38;2;1;2;3mc"+
+		"\x1b[0;4;7md"+
+		"\x1b[0m \x1b[0m\n")
+}
+
+func TestGoldenInput(t *testing.T) {
+	s := NewMemoryScreen(12, 1)
+	s.Type("Hello, World").Press(termbox.KeyHome, termbox.KeyArrowRight).
+		Type("i").Press(termbox.KeyEnter)
+	useTestScreen(t, s)
+	input := Input(1, 0, 10, 0, 0)
+	_, err := input.WaitExit()
+	assert.Nil(t, err)
+	assert.Equal(t, input.Text(), "Hiello, World")
+	assertGolden(t, "input.golden", snapshot(s))
+}
+
+func TestGoldenTextarea(t *testing.T) {
+	s := NewMemoryScreen(12, 5)
+	s.Type("The quick brown fox").Press(termbox.KeyEnter).
+		Type("jumps").Press(termbox.KeyArrowUp, termbox.KeyEsc)
+	useTestScreen(t, s)
+	textarea := Textarea(1, 1, 10, 3, 0, 0, true)
+	_, err := textarea.WaitExit()
+	assert.Nil(t, err)
+	assertGolden(t, "textarea.golden", snapshot(s))
+}
+
+func TestGoldenSelect(t *testing.T) {
+	s := NewMemoryScreen(8, 3)
+	s.Press(termbox.KeyArrowDown, termbox.KeyArrowDown, termbox.KeyArrowDown,
+		termbox.KeyEnter)
+	useTestScreen(t, s)
+	sbox := Select(0, 0, 8, 3,
+		termbox.ColorWhite, termbox.ColorBlue,
+		termbox.ColorBlack, termbox.ColorCyan,
+		[]string{"foo", "bar", "baz", "qux", "quux"},
+	)
+	_, err := sbox.WaitExit()
+	assert.Nil(t, err)
+	assert.Equal(t, sbox.Text(), "qux")
+	assertGolden(t, "select.golden", s.ANSI())
+}
+
+func TestGoldenLabel(t *testing.T) {
+	s := NewMemoryScreen(12, 2)
+	useTestScreen(t, s)
+	Label(0, 0, 8, termbox.ColorYellow|termbox.AttrBold, termbox.ColorBlack,
+		"Name:")
+	Label(0, 1, 8, termbox.ColorDefault, termbox.ColorDefault,
+		"Truncated text")
+	assertGolden(t, "label.golden", s.ANSI())
+}
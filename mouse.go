@@ -0,0 +1,138 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"time"
+)
+
+// Max delay between clicks of double click
+const doubleClickDelay = 400 * time.Millisecond
+
+// Lines scrolled by one mouse wheel step
+const wheelLines = 3
+
+// Enables mouse events of current screen if it implements MouseScreen.
+// Screens which always report mouse events, like MemoryScreen, do not
+// need it.
+func EnableMouse() {
+	if s, ok := screen.(MouseScreen); ok {
+		s.EnableMouse()
+	}
+}
+
+type mouseState struct {
+	dragging   bool
+	press      cursor
+	lastClick  time.Time
+	lastCursor cursor
+	// Alt-drag selects rectangular block
+	block bool
+}
+
+// Returns current time of widget clock
+func (ebox *Editbox) now() time.Time {
+	if ebox.clock != nil {
+		return ebox.clock()
+	}
+	return time.Now()
+}
+
+// Returns true if screen coordinates x, y are inside widget
+func (ebox *Editbox) contains(x, y int) bool {
+	return x >= ebox.x && x < ebox.x+ebox.width &&
+		y >= ebox.y && y < ebox.y+ebox.height
+}
+
+// Returns false if event is not used by widget
+func (ebox *Editbox) handleMouse(ev termbox.Event) bool {
+	ed := ebox.editor
+	switch ev.Key {
+	case termbox.MouseLeft:
+		p, inside := ebox.ScreenToPosition(ev.MouseX, ev.MouseY)
+		pos := ed.clamp(p)
+		if ev.Mod&termbox.ModMotion != 0 {
+			return ebox.drag(pos, ev.MouseX-ebox.x+ebox.scroll.x)
+		}
+		if !inside {
+			return false
+		}
+		ed.clearCarets()
+		ed.clearSelection()
+		ed.cursor = pos
+		ed.lastx = pos.x
+		ebox.freeScroll = false
+		t := ebox.now()
+		if pos == ebox.mouse.lastCursor &&
+			t.Sub(ebox.mouse.lastClick) < doubleClickDelay {
+			ebox.selectWord()
+			ebox.mouse.lastClick = time.Time{}
+			return true
+		}
+		ebox.mouse = mouseState{dragging: true, press: pos, lastClick: t,
+			lastCursor: pos, block: ev.Mod&termbox.ModAlt != 0 && !ebox.wrap}
+	case termbox.MouseRelease:
+		if !ebox.mouse.dragging {
+			return false
+		}
+		ebox.mouse.dragging = false
+	case termbox.MouseWheelUp:
+		ebox.scrollBy(-wheelLines)
+	case termbox.MouseWheelDown:
+		ebox.scrollBy(+wheelLines)
+	default:
+		return false
+	}
+	return true
+}
+
+// Block may span columns past the end of dragged line, so its right
+// column is taken from pointer column col. Block is dragged in no wrap
+// mode only where col is screen column plus scroll.
+func (ebox *Editbox) drag(pos cursor, col int) bool {
+	if !ebox.mouse.dragging {
+		return false
+	}
+	ed := ebox.editor
+	if !ed.selecting {
+		// Text may be edited since press
+		press := ebox.mouse.press
+		ed.cursor = ed.clamp(Position{Line: press.y, Col: press.x})
+		if ebox.mouse.block {
+			ed.startBlockSelection()
+		} else {
+			ed.startSelection()
+		}
+	}
+	ed.cursor = pos
+	ed.lastx = pos.x
+	if ebox.mouse.block && col > pos.x {
+		ed.lastx = col
+	}
+	ebox.freeScroll = false
+	return true
+}
+
+func (ebox *Editbox) selectWord() {
+	ed := ebox.editor
+	start, end, ok := ed.wordAt(ed.cursor)
+	if !ok {
+		return
+	}
+	ed.anchor = start
+	ed.selecting = true
+	ed.cursor = end
+	ed.lastx = end.x
+}
+
+// Scrolls view by dy lines without moving cursor
+func (ebox *Editbox) scrollBy(dy int) {
+	ebox.updateLineOffsets()
+	ebox.scroll.y += dy
+	if max := ebox.virtualHeight - ebox.height; ebox.scroll.y > max {
+		ebox.scroll.y = max
+	}
+	if ebox.scroll.y < 0 {
+		ebox.scroll.y = 0
+	}
+	ebox.freeScroll = true
+}
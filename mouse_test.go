@@ -0,0 +1,130 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Support
+// ----------------------------------------------------------------------------
+
+func sendMouse(eb *Editbox, key termbox.Key, mod termbox.Modifier, x, y int) {
+	eb.HandleEvent(termbox.Event{
+		Type: termbox.EventMouse, Key: key, Mod: mod, MouseX: x, MouseY: y,
+	})
+	eb.renderView()
+}
+
+func setNow(eb *Editbox, t time.Time) {
+	eb.clock = func() time.Time { return t }
+}
+
+// ----------------------------------------------------------------------------
+
+func TestMouseClick(t *testing.T) {
+	eb := newEditbox(1, 1, 3, 3, options{wrap: true})
+	eb.SetText("1234567\n12")
+	eb.SetCursor(0, 0)
+	eb.renderView()
+	sendMouse(eb, termbox.MouseLeft, 0, 2, 2)
+	assert.Equal(t, eb.editor.cursor, cursor{4, 0})
+	sendMouse(eb, termbox.MouseRelease, 0, 2, 2)
+	// Past the end of line
+	sendMouse(eb, termbox.MouseLeft, 0, 3, 3)
+	assert.Equal(t, eb.editor.cursor, cursor{7, 0})
+	// Outside
+	sendMouse(eb, termbox.MouseLeft, 0, 0, 0)
+	assert.Equal(t, eb.editor.cursor, cursor{7, 0})
+}
+
+func TestMouseDrag(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("foo bar\nbaz")
+	eb.renderView()
+	sendMouse(eb, termbox.MouseLeft, 0, 1, 0)
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 3, 0)
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 1, 1)
+	sendMouse(eb, termbox.MouseRelease, 0, 1, 1)
+	assert.Equal(t, eb.SelectedText(), "oo bar\nb")
+	// Motion without press does not select
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 0, 0)
+	assert.Equal(t, eb.SelectedText(), "oo bar\nb")
+}
+
+func TestMouseDragAfterEdit(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("\n")
+	eb.renderView()
+	sendMouse(eb, termbox.MouseLeft, termbox.ModAlt, 0, 1)
+	sendAltKey(eb, 0, 'k')
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 0, 0)
+	SetClipboardText("x")
+	sendKey(eb, termbox.KeyCtrlV)
+	assert.Equal(t, eb.Text(), "x")
+
+	eb = newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("ab")
+	eb.renderView()
+	sendMouse(eb, termbox.MouseLeft, 0, 2, 0)
+	sendKey(eb, termbox.KeyCtrlA)
+	sendKey(eb, termbox.KeyEnter)
+	sendAltKey(eb, 0, 'd')
+	sendMouse(eb, termbox.MouseLeft, termbox.ModMotion, 0, 0)
+	sendKey(eb, termbox.KeyCtrlD)
+	assert.Equal(t, eb.Text(), "\n\n")
+}
+
+func TestMouseDoubleClick(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("foo bar")
+	eb.renderView()
+	start := time.Now()
+	setNow(eb, start)
+	sendMouse(eb, termbox.MouseLeft, 0, 5, 0)
+	sendMouse(eb, termbox.MouseRelease, 0, 5, 0)
+	setNow(eb, start.Add(doubleClickDelay+time.Millisecond))
+	sendMouse(eb, termbox.MouseLeft, 0, 5, 0)
+	sendMouse(eb, termbox.MouseRelease, 0, 5, 0)
+	assert.Equal(t, eb.SelectedText(), "")
+	setNow(eb, start.Add(doubleClickDelay+2*time.Millisecond))
+	sendMouse(eb, termbox.MouseLeft, 0, 5, 0)
+	assert.Equal(t, eb.SelectedText(), "bar")
+}
+
+func TestMouseWheel(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("1\n2\n3\n4\n5\n6\n7\n8")
+	eb.SetCursor(0, 0)
+	eb.renderView()
+	sendMouse(eb, termbox.MouseWheelDown, 0, 0, 0)
+	assert.Equal(t, eb.scroll.y, 3)
+	assert.Equal(t, eb.editor.cursor, cursor{0, 0})
+	sendMouse(eb, termbox.MouseWheelDown, 0, 0, 0)
+	assert.Equal(t, eb.scroll.y, 5)
+	sendMouse(eb, termbox.MouseWheelUp, 0, 0, 0)
+	assert.Equal(t, eb.scroll.y, 2)
+	// Key press scrolls back to cursor
+	sendKey(eb, termbox.KeyArrowRight)
+	eb.renderView()
+	assert.Equal(t, eb.scroll.y, 0)
+}
+
+func TestMouseWheelHidesCursor(t *testing.T) {
+	s := NewMemoryScreen(10, 5)
+	useTestScreen(t, s)
+	eb := Textarea(0, 1, 10, 3, 0, 0, false)
+	eb.SetText("1\n2\n3\n4\n5\n6\n7\n8")
+	eb.SetCursor(0, 0)
+	eb.Render()
+	x, y := s.Cursor()
+	assert.Equal(t, []int{x, y}, []int{0, 1})
+	eb.HandleEvent(termbox.Event{Type: termbox.EventMouse,
+		Key: termbox.MouseWheelDown, MouseX: 0, MouseY: 1})
+	eb.Render()
+	x, y = s.Cursor()
+	assert.Equal(t, []int{x, y}, []int{-1, -1})
+	assert.Equal(t, s.String(), "\n4\n5\n6")
+}
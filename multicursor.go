@@ -0,0 +1,346 @@
+package editbox
+
+import (
+	"sort"
+)
+
+// Additional cursor with its own selection.
+// Primary cursor and selection are kept in editor itself.
+type caret struct {
+	cursor    cursor
+	anchor    cursor
+	selecting bool
+}
+
+// Returns rune offset of position x, y from the beginning of text
+func (ed *editor) offset(c cursor) int {
+	offset := 0
+	for y := 0; y < c.y; y++ {
+		offset += len(ed.lines[y].text)
+	}
+	return offset + c.x
+}
+
+// Returns position of rune offset
+func (ed *editor) cursorAt(offset int) cursor {
+	for y, l := range ed.lines {
+		if offset < len(l.text) || y == len(ed.lines)-1 {
+			return cursor{offset, y}
+		}
+		offset -= len(l.text)
+	}
+	return cursor{}
+}
+
+// Returns text length in runes
+func (ed *editor) length() int {
+	return ed.offset(ed.endCursor())
+}
+
+func (ed *editor) endCursor() cursor {
+	y := len(ed.lines) - 1
+	return cursor{len(ed.lines[y].text), y}
+}
+
+func (ed *editor) primaryCaret() caret {
+	return caret{ed.cursor, ed.anchor, ed.selecting}
+}
+
+func (ed *editor) setCaret(c caret) {
+	ed.cursor, ed.anchor, ed.selecting = c.cursor, c.anchor, c.selecting
+	ed.lastx = ed.cursor.x
+}
+
+// Runs op at every cursor. Cursors are processed from the end of text
+// to the beginning so edit at one cursor does not affect positions
+// of cursors which are not processed yet. Already processed cursors
+// are shifted by edit length. Cursors which collide are merged before
+// and after op.
+func (ed *editor) forEachCursor(op func()) {
+	ed.mergeCarets()
+	if len(ed.carets) == 0 {
+		op()
+		return
+	}
+	carets := append([]caret{ed.primaryCaret()}, ed.carets...)
+	cursors := make([]int, len(carets))
+	anchors := make([]int, len(carets))
+	for i, c := range carets {
+		cursors[i] = ed.offset(c.cursor)
+		anchors[i] = cursors[i]
+		// Anchor of inactive selection may point outside of text
+		if c.selecting {
+			anchors[i] = ed.offset(c.anchor)
+		}
+	}
+	start := func(i int) int {
+		if carets[i].selecting && anchors[i] < cursors[i] {
+			return anchors[i]
+		}
+		return cursors[i]
+	}
+	order := make([]int, len(carets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return start(order[a]) > start(order[b])
+	})
+	for n, i := range order {
+		ed.setCaret(caret{
+			ed.cursorAt(cursors[i]), ed.cursorAt(anchors[i]), carets[i].selecting,
+		})
+		before := ed.length()
+		op()
+		delta := ed.length() - before
+		for _, j := range order[:n] {
+			cursors[j] += delta
+			anchors[j] += delta
+		}
+		cursors[i] = ed.offset(ed.cursor)
+		anchors[i] = cursors[i]
+		if ed.selecting {
+			anchors[i] = ed.offset(ed.anchor)
+		}
+		carets[i].selecting = ed.selecting
+	}
+	ed.setCaret(caret{
+		ed.cursorAt(cursors[0]), ed.cursorAt(anchors[0]), carets[0].selecting,
+	})
+	ed.carets = ed.carets[:0]
+	for i := 1; i < len(carets); i++ {
+		ed.carets = append(ed.carets, caret{
+			ed.cursorAt(cursors[i]), ed.cursorAt(anchors[i]), carets[i].selecting,
+		})
+	}
+	ed.mergeCarets()
+}
+
+// Returns selected text of every cursor in text order
+func (ed *editor) selectedTexts() []string {
+	var texts []string
+	ed.forEachCursor(func() {
+		texts = append([]string{ed.selectedText()}, texts...)
+	})
+	return texts
+}
+
+// Returns ordered offsets of caret selection. Caret without selection
+// has empty range at cursor.
+func (ed *editor) caretRange(c caret) (start, end int) {
+	start = ed.offset(c.cursor)
+	end = start
+	if c.selecting {
+		if a := ed.offset(c.anchor); a < start {
+			start = a
+		} else {
+			end = a
+		}
+	}
+	return start, end
+}
+
+// Returns true if ranges overlap. Empty range collides with range
+// it touches, so cursor at the edge of selection is merged into it.
+func rangesCollide(s1, e1, s2, e2 int) bool {
+	if s1 == e1 || s2 == e2 {
+		return s1 <= e2 && s2 <= e1
+	}
+	return s1 < e2 && s2 < e1
+}
+
+// Returns true if c collides with primary cursor or any caret
+func (ed *editor) caretCollides(c caret) bool {
+	start, end := ed.caretRange(c)
+	for _, other := range append([]caret{ed.primaryCaret()}, ed.carets...) {
+		s, e := ed.caretRange(other)
+		if rangesCollide(start, end, s, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merges carets which collide with primary cursor or with each other.
+// Merged caret selects union of ranges. Primary cursor always survives.
+func (ed *editor) mergeCarets() {
+	if len(ed.carets) == 0 {
+		return
+	}
+	carets := append([]caret{ed.primaryCaret()}, ed.carets...)
+	merged := make([]bool, len(carets))
+	for i := range carets {
+		if merged[i] {
+			continue
+		}
+		for changed := true; changed; {
+			changed = false
+			s1, e1 := ed.caretRange(carets[i])
+			for j := i + 1; j < len(carets); j++ {
+				if merged[j] {
+					continue
+				}
+				s2, e2 := ed.caretRange(carets[j])
+				if !rangesCollide(s1, e1, s2, e2) {
+					continue
+				}
+				merged[j], changed = true, true
+				if s2 < s1 {
+					s1 = s2
+				}
+				if e2 > e1 {
+					e1 = e2
+				}
+				carets[i] = ed.spanCaret(carets[i], s1, e1)
+			}
+		}
+	}
+	ed.setCaret(carets[0])
+	ed.carets = ed.carets[:0]
+	for i := 1; i < len(carets); i++ {
+		if !merged[i] {
+			ed.carets = append(ed.carets, carets[i])
+		}
+	}
+}
+
+// Returns caret selecting start to end in direction of c
+func (ed *editor) spanCaret(c caret, start, end int) caret {
+	if start == end {
+		return caret{cursor: ed.cursorAt(start)}
+	}
+	if c.selecting && ed.offset(c.anchor) > ed.offset(c.cursor) {
+		start, end = end, start
+	}
+	return caret{
+		cursor: ed.cursorAt(end), anchor: ed.cursorAt(start), selecting: true,
+	}
+}
+
+// Adds caret unless it collides with primary cursor or another caret
+func (ed *editor) addCaret(c caret) bool {
+	if ed.caretCollides(c) {
+		return false
+	}
+	ed.carets = append(ed.carets, c)
+	return true
+}
+
+func (ed *editor) clearCarets() {
+	ed.carets = nil
+}
+
+// Adds cursor on line above topmost cursor (dy = -1)
+// or below bottommost cursor (dy = +1)
+func (ed *editor) addCaretVert(dy int) {
+	if ed.block {
+		ed.clearSelection()
+	}
+	edge := ed.cursor
+	for _, c := range ed.carets {
+		if c.cursor.y*dy > edge.y*dy {
+			edge = c.cursor
+		}
+	}
+	y := edge.y + dy
+	if y < 0 || y > len(ed.lines)-1 {
+		return
+	}
+	x := edge.x
+	if last := ed.lines[y].lastRuneX(); x > last {
+		x = last
+	}
+	ed.addCaret(caret{cursor: cursor{x, y}})
+}
+
+// Selects next occurrence of selected text and adds cursor to its end.
+// Search starts after the last selection and wraps around.
+func (ed *editor) addCaretAtNextOccurrence() bool {
+	if ed.block {
+		return false
+	}
+	s := ed.selectedText()
+	if s == "" {
+		return false
+	}
+	runes := []rune(ed.text())
+	sub := []rune(s)
+	_, from := ed.caretRange(ed.primaryCaret())
+	for _, c := range ed.carets {
+		if _, end := ed.caretRange(c); end > from {
+			from = end
+		}
+	}
+	for i := 0; i < len(runes); i++ {
+		start := (from + i) % len(runes)
+		if !hasRunesAt(runes, sub, start) {
+			continue
+		}
+		c := caret{
+			cursor:    ed.cursorAt(start + len(sub)),
+			anchor:    ed.cursorAt(start),
+			selecting: true,
+		}
+		if ed.addCaret(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRunesAt(runes, sub []rune, pos int) bool {
+	if pos+len(sub) > len(runes) {
+		return false
+	}
+	for i, r := range sub {
+		if runes[pos+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if x, y is selected by any additional cursor
+func (ed *editor) isSelectedByCaret(x, y int) bool {
+	for _, c := range ed.carets {
+		if !c.selecting {
+			continue
+		}
+		start, end := orderedRange(c.anchor, c.cursor)
+		if inRange(start, end, x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Adds cursor on the line above the topmost cursor.
+// Typing and deletion are applied at all cursors.
+func (ebox *Editbox) AddCursorAbove() {
+	ebox.editor.addCaretVert(-1)
+}
+
+// Adds cursor on the line below the bottommost cursor.
+func (ebox *Editbox) AddCursorBelow() {
+	ebox.editor.addCaretVert(+1)
+}
+
+// Selects next occurrence of selected text and adds cursor to it.
+// Returns false if there is no selection or no more occurrences.
+func (ebox *Editbox) AddCursorAtNextOccurrence() bool {
+	return ebox.editor.addCaretAtNextOccurrence()
+}
+
+// Removes all cursors except primary one.
+func (ebox *Editbox) ClearCursors() {
+	ebox.editor.clearCarets()
+}
+
+// Returns number of cursors including primary one.
+func (ebox *Editbox) CursorCount() int {
+	return len(ebox.editor.carets) + 1
+}
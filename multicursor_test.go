@@ -0,0 +1,174 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEditorOffset(t *testing.T) {
+	ed := newEditor()
+	ed.setText("12\n\n345")
+	assert.Equal(t, ed.offset(cursor{0, 0}), 0)
+	assert.Equal(t, ed.offset(cursor{2, 0}), 2)
+	assert.Equal(t, ed.offset(cursor{0, 1}), 3)
+	assert.Equal(t, ed.offset(cursor{3, 2}), 7)
+	assert.Equal(t, ed.cursorAt(2), cursor{2, 0})
+	assert.Equal(t, ed.cursorAt(3), cursor{0, 1})
+	assert.Equal(t, ed.cursorAt(4), cursor{0, 2})
+	assert.Equal(t, ed.cursorAt(7), cursor{3, 2})
+	assert.Equal(t, ed.length(), 7)
+}
+
+func TestCursorsBelowInsertAndDelete(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("foo\nbar\nxyz\nbaz")
+	eb.SetCursor(2, 0)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlN)
+	sendKey(eb, termbox.KeyCtrlN)
+	sendKey(eb, termbox.KeyCtrlN)
+	assert.Equal(t, eb.CursorCount(), 4)
+	sendString(eb, "__")
+	assert.Equal(t, eb.Text(), "fo__o\nba__r\nxy__z\nba__z")
+	sendKey(eb, termbox.KeyBackspace)
+	sendKey(eb, termbox.KeyDelete)
+	assert.Equal(t, eb.Text(), "fo_\nba_\nxy_\nba_")
+	assert.Equal(t, eb.editor.cursor, cursor{3, 0})
+
+	eb.renderView()
+	assert.Equal(t, eb.highlights[1][3], hlCursor)
+	assert.Equal(t, eb.highlights[3][3], hlCursor)
+}
+
+func TestCursorsMerge(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("a\nb\nc")
+	eb.SetCursor(1, 2)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlP)
+	sendKey(eb, termbox.KeyCtrlP)
+	sendKey(eb, termbox.KeyCtrlP) // No effect
+	assert.Equal(t, eb.CursorCount(), 3)
+	sendKey(eb, termbox.KeyHome)
+	sendKey(eb, termbox.KeyBackspace)
+	assert.Equal(t, eb.Text(), "abc")
+	sendKey(eb, termbox.KeyBackspace)
+	assert.Equal(t, eb.Text(), "c")
+	assert.Equal(t, eb.CursorCount(), 1)
+}
+
+func TestCursorAtNextOccurrence(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("foo bar\nfoo baz foo")
+	eb.SetCursor(0, 1)
+	eb.updateLineOffsets()
+	assert.False(t, eb.AddCursorAtNextOccurrence())
+	sendKey(eb, termbox.KeyCtrlSpace)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyCtrlD)
+	sendKey(eb, termbox.KeyCtrlD) // Wraps around
+	assert.False(t, eb.AddCursorAtNextOccurrence())
+	assert.Equal(t, eb.CursorCount(), 3)
+	sendString(eb, "qux")
+	assert.Equal(t, eb.Text(), "qux bar\nqux baz qux")
+	sendKey(eb, termbox.KeyArrowUp)
+	assert.Equal(t, eb.CursorCount(), 1)
+}
+
+func TestCursorsPaste(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("foo foo!")
+	eb.SetCursor(0, 0)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlSpace)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyCtrlD)
+	SetClipboardText("Z")
+	sendKey(eb, termbox.KeyCtrlV)
+	sendString(eb, "Q")
+	assert.Equal(t, eb.Text(), "ZQ ZQ!")
+	assert.Equal(t, eb.CursorCount(), 2)
+
+	// Every cursor gets its own line
+	SetClipboardText("1\n2")
+	sendKey(eb, termbox.KeyCtrlV)
+	assert.Equal(t, eb.Text(), "ZQ1 ZQ2!")
+	sendKey(eb, termbox.KeyCtrlZ)
+	assert.Equal(t, eb.Text(), "ZQ ZQ!")
+}
+
+func TestCursorsCut(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("foo bar\nfoo baz")
+	eb.SetCursor(0, 0)
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlSpace)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyCtrlD)
+	sendKey(eb, termbox.KeyCtrlX)
+	assert.Equal(t, eb.Text(), " bar\n baz")
+	assert.Equal(t, ClipboardText(), "foo\nfoo")
+	sendString(eb, "x")
+	assert.Equal(t, eb.Text(), "x bar\nx baz")
+	sendKey(eb, termbox.KeyEnd)
+	sendKey(eb, termbox.KeyCtrlV)
+	assert.Equal(t, eb.Text(), "x barfoo\nx bazfoo")
+}
+
+func TestCursorsSelectAll(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("ab\ncd")
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyCtrlP)
+	sendKey(eb, termbox.KeyCtrlA)
+	assert.Equal(t, eb.CursorCount(), 1)
+	sendString(eb, "x")
+	assert.Equal(t, eb.Text(), "x")
+	sendKey(eb, termbox.KeyCtrlZ)
+	assert.Equal(t, eb.Text(), "ab\ncd")
+}
+
+func TestCursorsOverlappingSelectionsMerge(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("abcdef")
+	eb.SetCursor(1, 0)
+	eb.ToggleSelection()
+	eb.SetCursor(4, 0)
+	eb.editor.carets = []caret{
+		{cursor: cursor{2, 0}, anchor: cursor{5, 0}, selecting: true},
+		{cursor: cursor{0, 0}},
+	}
+	sendString(eb, "x")
+	assert.Equal(t, eb.Text(), "xaxf")
+	assert.Equal(t, eb.CursorCount(), 2)
+}
+
+func TestCursorAtNextOccurrenceBackwards(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	sendString(eb, "foo")
+	sendKey(eb, termbox.KeyCtrlSpace)
+	sendKey(eb, termbox.KeyHome)
+	sendKey(eb, termbox.KeyCtrlD) // Selection itself is not added
+	assert.Equal(t, eb.CursorCount(), 1)
+	sendKey(eb, termbox.KeyEnter)
+	assert.Equal(t, eb.Text(), "\n")
+
+	eb = newEditbox(0, 0, 20, 5, options{})
+	sendString(eb, "foo foo foo")
+	eb.SetCursor(3, 0)
+	eb.ToggleSelection()
+	eb.SetCursor(0, 0)
+	sendKey(eb, termbox.KeyCtrlD)
+	sendKey(eb, termbox.KeyCtrlD)
+	sendKey(eb, termbox.KeyCtrlD) // Wraps around to selection itself
+	assert.Equal(t, eb.CursorCount(), 3)
+	sendString(eb, "x")
+	assert.Equal(t, eb.Text(), "x x x")
+}
@@ -0,0 +1,154 @@
+package editbox
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Indentation with quote markers followed by optional list item marker.
+// List marker must be followed by whitespace, so "3.14" is not a marker.
+var prefixRegexp = regexp.MustCompile(`^([ \t]*(?:>[ \t]?)*)((?:[-*+]|\d+[.)])[ \t]+)?`)
+
+// Splits line into prefix and text. Prefix of continuation lines keeps
+// indentation and quote markers but replaces list marker with spaces.
+func splitPrefix(s string) (prefix, contPrefix, text string, item bool) {
+	m := prefixRegexp.FindStringSubmatch(s)
+	prefix = m[0]
+	contPrefix = m[1] + strings.Repeat(" ", len([]rune(m[2])))
+	text = strings.TrimSpace(s[len(prefix):])
+	return prefix, contPrefix, text, m[2] != ""
+}
+
+func isBlank(s string) bool {
+	_, _, text, item := splitPrefix(s)
+	return text == "" && !item
+}
+
+// Returns true if line starts new paragraph
+func startsParagraph(s string) bool {
+	_, _, _, item := splitPrefix(s)
+	return item
+}
+
+// Returns true if lines have the same quote markers, so they may
+// belong to the same paragraph
+func sameQuote(a, b string) bool {
+	quote := func(s string) string {
+		m := prefixRegexp.FindStringSubmatch(s)
+		return strings.Map(func(r rune) rune {
+			if r == '>' {
+				return r
+			}
+			return -1
+		}, m[1])
+	}
+	return quote(a) == quote(b)
+}
+
+// Reflows paragraphs so no line is longer than width runes unless
+// it contains single long word. Blank lines separate paragraphs and
+// are kept as is. List items and change of quote markers start new
+// paragraphs.
+func reflowLines(lines []string, width int) []string {
+	result := []string{}
+	for i := 0; i < len(lines); {
+		if isBlank(lines[i]) {
+			result = append(result, lines[i])
+			i++
+			continue
+		}
+		first := lines[i]
+		prefix, contPrefix, text, _ := splitPrefix(first)
+		words := strings.Fields(text)
+		for i++; i < len(lines); i++ {
+			if isBlank(lines[i]) || startsParagraph(lines[i]) ||
+				!sameQuote(first, lines[i]) {
+				break
+			}
+			_, _, text, _ = splitPrefix(lines[i])
+			words = append(words, strings.Fields(text)...)
+		}
+		result = append(result, fillWords(words, prefix, contPrefix, width)...)
+	}
+	return result
+}
+
+func fillWords(words []string, prefix, contPrefix string, width int) []string {
+	lines := []string{}
+	line := prefix
+	n := len([]rune(line))
+	empty := true
+	for _, word := range words {
+		w := len([]rune(word))
+		if !empty && n+1+w > width {
+			lines = append(lines, line)
+			line = contPrefix
+			n = len([]rune(line))
+			empty = true
+		}
+		if !empty {
+			line += " "
+			n++
+		}
+		line += word
+		n += w
+		empty = false
+	}
+	return append(lines, strings.TrimRight(line, " \t"))
+}
+
+// Returns bounds of paragraph at line y.
+// Returns false if line y is blank.
+func (ed *editor) paragraphAt(y int) (top, bottom int, ok bool) {
+	if isBlank(ed.lineText(y)) {
+		return 0, 0, false
+	}
+	top, bottom = y, y
+	for top > 0 && !startsParagraph(ed.lineText(top)) &&
+		!isBlank(ed.lineText(top-1)) &&
+		sameQuote(ed.lineText(top-1), ed.lineText(top)) {
+		top--
+	}
+	for bottom < len(ed.lines)-1 && !isBlank(ed.lineText(bottom+1)) &&
+		!startsParagraph(ed.lineText(bottom+1)) &&
+		sameQuote(ed.lineText(bottom), ed.lineText(bottom+1)) {
+		bottom++
+	}
+	return top, bottom, true
+}
+
+// Reflows selected lines or paragraph at cursor
+func (ed *editor) reflow(width int) {
+	top, bottom := ed.selectedLines()
+	if !ed.selecting {
+		var ok bool
+		if top, bottom, ok = ed.paragraphAt(ed.cursor.y); !ok {
+			return
+		}
+	}
+	lines := []string{}
+	for y := top; y <= bottom; y++ {
+		lines = append(lines, ed.lineText(y))
+	}
+	ed.clearSelection()
+	ed.replaceLines(top, bottom, reflowLines(lines, width))
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Hard wraps selected lines or paragraph under cursor so lines are not
+// longer than width. Indentation, quote (>) and list (-, *, +, 1.)
+// prefixes are preserved. Does nothing in read-only mode.
+func (ebox *Editbox) Reflow(width int) {
+	if width <= 0 {
+		return
+	}
+	ebox.editCommand(func() { ebox.editor.reflow(width) })
+}
+
+// Sets width used by Alt+Q reflow command. Widget width is used by default.
+func (ebox *Editbox) SetReflowWidth(width int) {
+	ebox.reflowWidth = width
+}
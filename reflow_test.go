@@ -0,0 +1,57 @@
+package editbox
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReflowLines(t *testing.T) {
+	assert.Equal(t, reflowLines([]string{
+		"Lorem ipsum dolor sit amet, consectetur",
+		"adipiscing elit",
+		"",
+		"  > - sed do eiusmod tempor incididunt",
+		"  > ut labore",
+		"  > - et dolore",
+		"> > nested",
+		"supercalifragilisticexpialidocious",
+	}, 20), []string{
+		"Lorem ipsum dolor",
+		"sit amet,",
+		"consectetur",
+		"adipiscing elit",
+		"",
+		"  > - sed do eiusmod",
+		"  >   tempor",
+		"  >   incididunt ut",
+		"  >   labore",
+		"  > - et dolore",
+		"> > nested",
+		"supercalifragilisticexpialidocious",
+	})
+	// Not list item
+	assert.Equal(t, reflowLines([]string{"pi is", "3.14 here"}, 20),
+		[]string{"pi is 3.14 here"})
+}
+
+func TestReflowParagraph(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("Title\n\nfoo bar\nbaz qux quux\n\n1. end")
+	eb.SetCursor(0, 3)
+	sendAltKey(eb, 0, 'q')
+	assert.Equal(t, eb.Text(), "Title\n\nfoo bar\nbaz qux\nquux\n\n1. end")
+	eb.Reflow(72)
+	assert.Equal(t, eb.Text(), "Title\n\nfoo bar baz qux quux\n\n1. end")
+	eb.SetCursor(0, 1)
+	eb.Reflow(3) // Blank line. No effect
+	assert.Equal(t, eb.Text(), "Title\n\nfoo bar baz qux quux\n\n1. end")
+	eb.SelectAll()
+	eb.Reflow(7)
+	assert.Equal(t, eb.Text(), "Title\n\nfoo bar\nbaz qux\nquux\n\n1. end")
+
+	eb = newEditbox(0, 0, 10, 5, options{})
+	eb.SetText("> foo\n> bar\nbaz")
+	eb.SetCursor(0, 0)
+	eb.Reflow(72)
+	assert.Equal(t, eb.Text(), "> foo bar\nbaz")
+}
@@ -0,0 +1,105 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+)
+
+// Function called on terminal resize with new terminal size.
+// Use it to move or resize widget with SetPosition and SetSize.
+type ResizeFunc func(termWidth, termHeight int)
+
+//----------------------------------------------------------------------------
+// Editbox
+//----------------------------------------------------------------------------
+
+// Returns widget position and size.
+func (ebox *Editbox) Bounds() (x, y, width, height int) {
+	return ebox.x, ebox.y, ebox.width, ebox.height
+}
+
+// Moves widget to x, y. This DOES NOT clear old widget area.
+func (ebox *Editbox) SetPosition(x, y int) {
+	ebox.x = x
+	ebox.y = y
+}
+
+// Changes widget size. Text is rewrapped and scrolled to keep cursor
+// visible. This DOES NOT clear old widget area.
+// For autoexpanding widget height becomes its minimal height.
+func (ebox *Editbox) SetSize(width, height int) {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	ebox.width = width
+	ebox.height = height
+	if ebox.autoexpand {
+		ebox.minHeight = height
+		if ebox.maxHeight < height {
+			ebox.maxHeight = height
+		}
+	}
+	ebox.freeScroll = false
+	ebox.updateLineOffsets()
+	ebox.scrollToCursor()
+}
+
+// Sets function called when terminal is resized.
+func (ebox *Editbox) OnResize(f ResizeFunc) {
+	ebox.onResize = f
+}
+
+func (ebox *Editbox) handleResize(ev termbox.Event) {
+	if ebox.onResize != nil {
+		ebox.onResize(ev.Width, ev.Height)
+	}
+	ebox.freeScroll = false
+}
+
+//----------------------------------------------------------------------------
+// SelectBox
+//----------------------------------------------------------------------------
+
+// Returns widget position and size.
+func (sbox *SelectBox) Bounds() (x, y, width, height int) {
+	return sbox.x, sbox.y, sbox.width, sbox.height
+}
+
+// Moves widget to x, y. This DOES NOT clear old widget area.
+func (sbox *SelectBox) SetPosition(x, y int) {
+	sbox.x = x
+	sbox.y = y
+}
+
+// Changes widget size keeping selected item visible.
+// This DOES NOT clear old widget area.
+func (sbox *SelectBox) SetSize(width, height int) {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	sbox.width = width
+	sbox.height = height
+	if max := len(sbox.items) - height; sbox.scroll > max {
+		sbox.scroll = max
+	}
+	if sbox.scroll < 0 {
+		sbox.scroll = 0
+	}
+	sbox.scrollToCursor()
+}
+
+// Sets function called when terminal is resized.
+func (sbox *SelectBox) OnResize(f ResizeFunc) {
+	sbox.onResize = f
+}
+
+func (sbox *SelectBox) handleResize(ev termbox.Event) {
+	if sbox.onResize != nil {
+		sbox.onResize(ev.Width, ev.Height)
+	}
+}
@@ -0,0 +1,52 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEditboxResize(t *testing.T) {
+	eb := newEditbox(0, 0, 3, 3, options{wrap: true})
+	eb.SetText("1234567\n12\n1234\n1")
+	eb.OnResize(func(w, h int) {
+		eb.SetPosition(w/2, 1)
+		eb.SetSize(w/2, h-2)
+	})
+	eb.renderView()
+	assert.Equal(t, eb.scroll.y, 4)
+	eb.HandleEvent(termbox.Event{Type: termbox.EventResize, Width: 10, Height: 5})
+	x, y, w, h := eb.Bounds()
+	assert.Equal(t, []int{x, y, w, h}, []int{5, 1, 5, 3})
+	eb.renderView()
+	assert.Equal(t, eb.lineBoxY, []int{0, 2, 3, 4})
+	assert.Equal(t, eb.scroll.y, 2)
+	assert.Equal(t, eb.cursor, cursor{1, 4})
+
+	eb.SetSize(0, 0)
+	_, _, w, h = eb.Bounds()
+	assert.Equal(t, []int{w, h}, []int{1, 1})
+}
+
+func TestSelectResize(t *testing.T) {
+	s := Select(
+		0, 0, 3, 3,
+		0, 0, 0, 0,
+		[]string{"foo", "bar", "baz", "qux", "xyz"},
+	)
+	s.OnResize(func(w, h int) {
+		s.SetSize(w, h-3)
+	})
+	s.cursorDown()
+	s.cursorDown()
+	s.cursorDown()
+	s.cursorDown()
+	assert.Equal(t, s.scroll, 2)
+	assert.True(t, s.HandleEvent(
+		termbox.Event{Type: termbox.EventResize, Width: 10, Height: 7}))
+	assert.Equal(t, s.scroll, 1)
+	s.SetSize(10, 1)
+	assert.Equal(t, s.scroll, 4)
+	x, y, w, h := s.Bounds()
+	assert.Equal(t, []int{x, y, w, h}, []int{0, 0, 10, 1})
+}
@@ -0,0 +1,279 @@
+package editbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nsf/termbox-go"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Form definition loaded from JSON with LoadSchema.
+// Only JSON is parsed by this package. Fields also have yaml tags, so
+// YAML document unmarshaled into Schema by YAML library of your choice
+// can be passed to NewSchemaForm.
+type Schema struct {
+	Fields []SchemaField `json:"fields" yaml:"fields"`
+}
+
+// Form field definition. Type is one of
+//
+//   - string: Input, value is string
+//   - text: Textarea, value is string
+//   - integer: Input, value is int
+//   - number: Input, value is float64
+//   - boolean: Yes/No Select, value is bool
+//   - select: Select of Options, value is selected option
+//
+// Required fields must not be empty. Pattern is regular expression
+// string values must match. Min and Max limit numeric values.
+type SchemaField struct {
+	Name     string      `json:"name" yaml:"name"`
+	Type     string      `json:"type" yaml:"type"`
+	Label    string      `json:"label" yaml:"label"`
+	Default  interface{} `json:"default" yaml:"default"`
+	Required bool        `json:"required" yaml:"required"`
+	Pattern  string      `json:"pattern" yaml:"pattern"`
+	Min      *float64    `json:"min" yaml:"min"`
+	Max      *float64    `json:"max" yaml:"max"`
+	Options  []string    `json:"options" yaml:"options"`
+	Width    int         `json:"width" yaml:"width"`
+	Height   int         `json:"height" yaml:"height"`
+}
+
+// Error of invalid field value
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Parses JSON form definition.
+func LoadSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("editbox: schema: %v", err)
+	}
+	return &schema, nil
+}
+
+// Form built from Schema
+type SchemaForm struct {
+	*Form
+	fields   []SchemaField
+	patterns []*regexp.Regexp
+}
+
+// Builds form from schema. Widgets are filled with default values
+// and laid out as label-field rows.
+func NewSchemaForm(schema *Schema, fg, bg termbox.Attribute) (*SchemaForm, error) {
+	sf := &SchemaForm{Form: NewForm()}
+	layout := Fields()
+	names := make(map[string]bool)
+	for _, field := range schema.Fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("editbox: schema: field without name")
+		}
+		if names[field.Name] {
+			return nil, fmt.Errorf("editbox: schema: duplicate field %s",
+				field.Name)
+		}
+		names[field.Name] = true
+		if field.Label == "" {
+			field.Label = field.Name
+		}
+		if field.Width <= 0 {
+			field.Width = defaultFieldWidth
+		}
+		var pattern *regexp.Regexp
+		if field.Pattern != "" {
+			var err error
+			if pattern, err = regexp.Compile(field.Pattern); err != nil {
+				return nil, fmt.Errorf("editbox: schema: field %s: %v",
+					field.Name, err)
+			}
+		}
+		item, err := sf.addWidget(&field, fg, bg)
+		if err != nil {
+			return nil, err
+		}
+		sf.fields = append(sf.fields, field)
+		sf.patterns = append(sf.patterns, pattern)
+		layout.Add(field.Label+":", item)
+	}
+	sf.SetLayout(Pad(layout.Spacing(1), 1, 2, 1, 2))
+	return sf, nil
+}
+
+func formatDefault(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// Creates widget of field type and adds it to form
+func (sf *SchemaForm) addWidget(field *SchemaField, fg, bg termbox.Attribute) (Layout, error) {
+	text := formatDefault(field.Default)
+	items := field.Options
+	switch field.Type {
+	case "", "string", "integer", "number":
+		ebox := newInput(0, 0, field.Width, fg, bg)
+		ebox.SetText(text)
+		sf.AddEditbox(field.Name, ebox)
+		return Item(ebox), nil
+	case "text":
+		if field.Height <= 0 {
+			field.Height = defaultTextareaHeight
+		}
+		ebox := newTextarea(0, 0, field.Width, field.Height, fg, bg, true)
+		ebox.SetText(text)
+		sf.AddEditbox(field.Name, ebox)
+		return Item(ebox).Fill(true, true), nil
+	case "boolean":
+		items = confirmItems
+		if text == "true" {
+			text = confirmItems[0]
+		} else {
+			text = confirmItems[1]
+		}
+	case "select":
+		if len(items) == 0 {
+			return nil, fmt.Errorf("editbox: schema: field %s: "+
+				"select without options", field.Name)
+		}
+	default:
+		return nil, fmt.Errorf("editbox: schema: field %s: unknown type %q",
+			field.Name, field.Type)
+	}
+	sbox := Select(0, 0, field.Width, selectHeight(field.Height, items),
+		fg, bg, bg, fg, items)
+	for i, item := range items {
+		if item == text {
+			sbox.SetSelectedIndex(i)
+		}
+	}
+	sf.AddSelect(field.Name, sbox)
+	return Item(sbox), nil
+}
+
+// Converts and validates text of field
+func (sf *SchemaForm) value(i int, text string) (interface{}, error) {
+	field := sf.fields[i]
+	invalid := func(format string, args ...interface{}) error {
+		return &ValidationError{field.Name, fmt.Sprintf(format, args...)}
+	}
+	trimmed := strings.TrimSpace(text)
+	if field.Required && trimmed == "" {
+		return nil, invalid("value required")
+	}
+	switch field.Type {
+	case "boolean":
+		return text == confirmItems[0], nil
+	case "integer":
+		if trimmed == "" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(trimmed)
+		if err != nil {
+			return nil, invalid("invalid integer %q", text)
+		}
+		if err := sf.checkRange(field, float64(n)); err != "" {
+			return nil, invalid("%s", err)
+		}
+		return n, nil
+	case "number":
+		if trimmed == "" {
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, invalid("invalid number %q", text)
+		}
+		if err := sf.checkRange(field, n); err != "" {
+			return nil, invalid("%s", err)
+		}
+		return n, nil
+	}
+	// Optional field may be left blank whatever its pattern is
+	if trimmed == "" && !field.Required {
+		return text, nil
+	}
+	if p := sf.patterns[i]; p != nil && !p.MatchString(text) {
+		return nil, invalid("does not match %s", field.Pattern)
+	}
+	return text, nil
+}
+
+// Returns message if n is out of field range
+func (sf *SchemaForm) checkRange(field SchemaField, n float64) string {
+	if field.Min != nil && n < *field.Min {
+		return "must be at least " + formatDefault(*field.Min)
+	}
+	if field.Max != nil && n > *field.Max {
+		return "must be at most " + formatDefault(*field.Max)
+	}
+	return ""
+}
+
+// Returns validated values of all fields. Empty integer and number
+// fields are nil. Returns *ValidationError if any value is invalid.
+func (sf *SchemaForm) Result() (map[string]interface{}, error) {
+	texts := sf.Values()
+	result := make(map[string]interface{})
+	for i, field := range sf.fields {
+		v, err := sf.value(i, texts[field.Name])
+		if err != nil {
+			return nil, err
+		}
+		result[field.Name] = v
+	}
+	return result, nil
+}
+
+// Returns validated values of all fields as JSON object.
+func (sf *SchemaForm) ResultJSON() ([]byte, error) {
+	result, err := sf.Result()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// Runs form until it is cancelled or submitted with valid values.
+// Invalid field is focused and error is passed to onError if it is
+// not nil. Returns true if form was submitted and event which
+// finished form. Returns *TerminalError on termbox error event and
+// error of History.Add as is if values cannot be saved to history.
+func (sf *SchemaForm) Run(onError func(error)) (bool, termbox.Event, error) {
+	return sf.RunContext(context.Background(), onError)
+}
+
+// Same as Run but also returns ctx.Err() when ctx is cancelled
+// or its deadline is exceeded.
+func (sf *SchemaForm) RunContext(
+	ctx context.Context,
+	onError func(error),
+) (bool, termbox.Event, error) {
+	for {
+		submitted, ev, err := sf.Form.RunContext(ctx)
+		if !submitted || err != nil {
+			return submitted, ev, err
+		}
+		if _, err = sf.Result(); err == nil {
+			return true, ev, nil
+		}
+		sf.Focus(err.(*ValidationError).Field)
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
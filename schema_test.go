@@ -0,0 +1,109 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testSchema = `{
+	"fields": [
+		{"name": "host", "label": "Host", "required": true,
+			"pattern": "^[a-z.]+$", "default": "localhost"},
+		{"name": "port", "type": "integer", "default": 8080,
+			"min": 1, "max": 65535},
+		{"name": "ratio", "type": "number"},
+		{"name": "tls", "type": "boolean", "default": true},
+		{"name": "level", "type": "select", "options": ["low", "high"],
+			"default": "high"},
+		{"name": "notes", "type": "text", "height": 2}
+	]
+}`
+
+func TestSchemaForm(t *testing.T) {
+	schema, err := LoadSchema([]byte(testSchema))
+	assert.Nil(t, err)
+	sf, err := NewSchemaForm(schema, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, sf.Values(), map[string]string{
+		"host":  "localhost",
+		"port":  "8080",
+		"ratio": "",
+		"tls":   "Yes",
+		"level": "high",
+		"notes": "",
+	})
+	result, err := sf.Result()
+	assert.Nil(t, err)
+	assert.Equal(t, result, map[string]interface{}{
+		"host":  "localhost",
+		"port":  8080,
+		"ratio": nil,
+		"tls":   true,
+		"level": "high",
+		"notes": "",
+	})
+
+	formString(sf.Form, "1")
+	_, err = sf.Result()
+	assert.Equal(t, err, &ValidationError{"host", "does not match ^[a-z.]+$"})
+	formKey(sf.Form, termbox.KeyBackspace2)
+	formKey(sf.Form, termbox.KeyTab)
+	formString(sf.Form, "0")
+	_, err = sf.Result()
+	assert.Equal(t, err.Error(), "port: must be at most 65535")
+	formKey(sf.Form, termbox.KeyBackspace2)
+	formKey(sf.Form, termbox.KeyTab)
+	formString(sf.Form, "x")
+	_, err = sf.Result()
+	assert.Equal(t, err.Error(), `ratio: invalid number "x"`)
+	formKey(sf.Form, termbox.KeyBackspace2)
+	formString(sf.Form, "0.5")
+	formKey(sf.Form, termbox.KeyTab)
+	formKey(sf.Form, termbox.KeyArrowDown)
+
+	json, err := sf.ResultJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, string(json), `{"host":"localhost","level":"high",`+
+		`"notes":"","port":8080,"ratio":0.5,"tls":false}`)
+}
+
+func TestSchemaErrors(t *testing.T) {
+	_, err := LoadSchema([]byte(`{"fields": 1}`))
+	assert.NotNil(t, err)
+	for _, c := range []struct{ schema, err string }{
+		{`{"fields": [{"type": "string"}]}`,
+			"editbox: schema: field without name"},
+		{`{"fields": [{"name": "a"}, {"name": "a"}]}`,
+			"editbox: schema: duplicate field a"},
+		{`{"fields": [{"name": "a", "type": "date"}]}`,
+			`editbox: schema: field a: unknown type "date"`},
+		{`{"fields": [{"name": "a", "type": "select"}]}`,
+			"editbox: schema: field a: select without options"},
+	} {
+		schema, err := LoadSchema([]byte(c.schema))
+		assert.Nil(t, err)
+		_, err = NewSchemaForm(schema, 0, 0)
+		assert.Equal(t, err.Error(), c.err)
+	}
+}
+
+func TestSchemaRequired(t *testing.T) {
+	schema, _ := LoadSchema([]byte(
+		`{"fields": [{"name": "a", "required": true}]}`))
+	sf, _ := NewSchemaForm(schema, 0, 0)
+	_, err := sf.Result()
+	assert.Equal(t, err.Error(), "a: value required")
+}
+
+func TestSchemaOptionalPattern(t *testing.T) {
+	schema, _ := LoadSchema([]byte(
+		`{"fields": [{"name": "zip", "pattern": "^[0-9]{5}$"}]}`))
+	sf, _ := NewSchemaForm(schema, 0, 0)
+	result, err := sf.Result()
+	assert.Nil(t, err)
+	assert.Equal(t, result, map[string]interface{}{"zip": ""})
+	formString(sf.Form, "123")
+	_, err = sf.Result()
+	assert.Equal(t, err.Error(), "zip: does not match ^[0-9]{5}$")
+}
@@ -0,0 +1,97 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+)
+
+// Terminal widgets draw on. Screen is also source of terminal events.
+type Screen interface {
+	EventSource
+	// Changes cell in back buffer. Cells outside of screen are ignored.
+	SetCell(x, y int, ch rune, fg, bg termbox.Attribute)
+	// Moves cursor. Negative x or y hides cursor.
+	SetCursor(x, y int)
+	// Returns screen size in cells
+	Size() (width, height int)
+	// Fills back buffer with spaces of fg/bg colors
+	Clear(fg, bg termbox.Attribute)
+	// Shows back buffer on terminal
+	Flush() error
+}
+
+// Screen which reports mouse events only after they are enabled,
+// like terminal ones. EnableMouse enables them on current screen.
+type MouseScreen interface {
+	EnableMouse()
+}
+
+// Screen widgets draw on. Set with SetScreen.
+var screen Screen = TermboxScreen{}
+
+// Makes widgets draw on s and read events from it.
+// Default screen is TermboxScreen.
+func SetScreen(s Screen) {
+	screen = s
+	eventSource = s
+}
+
+// Returns screen widgets draw on.
+func GetScreen() Screen {
+	return screen
+}
+
+// First termbox attribute bit above attributes. termbox keeps RGB colors
+// above it.
+const maxAttr = termbox.AttrReverse << 1
+
+// Decodes color of termbox attribute for Screen implementations.
+// RGB colors of termbox.RGBToAttribute are returned in r, g, b with rgb
+// set. Otherwise index is termbox color number: ColorDefault is 0 and
+// palette colors are numbered from 1.
+func AttributeColor(attr termbox.Attribute) (index int, rgb bool, r, g, b uint8) {
+	if uint64(attr)/uint64(maxAttr) >= 1<<25 {
+		r, g, b = termbox.AttributeToRGB(attr)
+		return 0, true, r, g, b
+	}
+	return int(attr & (termbox.AttrBold - 1)), false, 0, 0, 0
+}
+
+// Hides screen cursor
+func hideCursor() {
+	screen.SetCursor(-1, -1)
+}
+
+// Draws on termbox. termbox.Init must be called before use.
+type TermboxScreen struct {
+	TermboxEvents
+}
+
+func (TermboxScreen) SetCell(x, y int, ch rune, fg, bg termbox.Attribute) {
+	termbox.SetCell(x, y, ch, fg, bg)
+}
+
+func (TermboxScreen) SetCursor(x, y int) {
+	termbox.SetCursor(x, y)
+}
+
+func (TermboxScreen) Size() (width, height int) {
+	return termbox.Size()
+}
+
+func (TermboxScreen) Clear(fg, bg termbox.Attribute) {
+	termbox.Clear(fg, bg)
+}
+
+func (TermboxScreen) Flush() error {
+	return termbox.Flush()
+}
+
+// Enables termbox mouse input mode keeping other input mode flags.
+// Does nothing if termbox is not initialized.
+func (TermboxScreen) EnableMouse() {
+	if !termbox.IsInit {
+		return
+	}
+	termbox.SetInputMode(termbox.SetInputMode(termbox.InputCurrent) |
+		termbox.InputMouse)
+}
@@ -0,0 +1,124 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Replaces screen for the duration of test
+func useTestScreen(t *testing.T, s Screen) {
+	oldScreen, oldEvents := screen, eventSource
+	t.Cleanup(func() {
+		screen, eventSource = oldScreen, oldEvents
+	})
+	SetScreen(s)
+}
+
+func TestSetScreen(t *testing.T) {
+	s := NewMemoryScreen(10, 1)
+	useTestScreen(t, s)
+	assert.Equal(t, GetScreen(), Screen(s))
+	assert.Equal(t, GetEventSource(), EventSource(s))
+}
+
+func TestLabelOnScreen(t *testing.T) {
+	s := NewMemoryScreen(10, 2)
+	useTestScreen(t, s)
+	Label(1, 0, 5, termbox.ColorRed, termbox.ColorBlue, "Hello, World")
+	Text(0, 1, 0, 0, 0, 0, "Hi")
+	assert.Equal(t, s.String(), " Hello\nHi")
+	assert.Equal(t, s.Cell(1, 0),
+		termbox.Cell{Ch: 'H', Fg: termbox.ColorRed, Bg: termbox.ColorBlue})
+}
+
+func TestInputWaitExitOnScreen(t *testing.T) {
+	s := NewMemoryScreen(20, 1)
+	s.Type("abc").Press(termbox.KeyArrowLeft, termbox.KeyEnter)
+	useTestScreen(t, s)
+	input := Input(2, 0, 10, 0, 0)
+	ev, err := input.WaitExit()
+	assert.Nil(t, err)
+	assert.Equal(t, ev.Key, termbox.KeyEnter)
+	assert.Equal(t, input.Text(), "abc")
+	assert.Equal(t, s.String(), "  abc")
+	x, y := s.Cursor()
+	assert.Equal(t, []int{x, y}, []int{4, 0})
+	assert.True(t, s.Flushes() > 0)
+}
+
+func TestConfirmOnScreen(t *testing.T) {
+	s := NewMemoryScreen(20, 1)
+	s.Type("xy")
+	useTestScreen(t, s)
+	yes, ev, err := Confirm(0, 0, 0, 0, "Save")
+	assert.Nil(t, err)
+	assert.True(t, yes)
+	assert.Equal(t, ev.Ch, 'y')
+	assert.Equal(t, s.String(), "Save [y/n]")
+
+	// Events are over
+	yes, _, err = Confirm(0, 0, 0, 0, "Save")
+	assert.False(t, yes)
+	assert.IsType(t, &TerminalError{}, err)
+}
+
+func TestConfirmButtons(t *testing.T) {
+	s := NewMemoryScreen(20, 1)
+	s.Press(termbox.KeyArrowRight, termbox.KeyEnter)
+	useTestScreen(t, s)
+	yes, ev, err := Confirm(0, 0, 0, 0, "Save?", "Yes", "No")
+	assert.Nil(t, err)
+	assert.False(t, yes)
+	assert.Equal(t, ev.Key, termbox.KeyEnter)
+	assert.Equal(t, s.String(), "Save?  <Yes>  <No>")
+
+	s.Press(termbox.KeyTab, KeyBacktab, termbox.KeySpace)
+	yes, _, _ = Confirm(0, 0, 0, 0, "Save?", "Yes", "No")
+	assert.True(t, yes)
+	s.Type("Y")
+	yes, _, _ = Confirm(0, 0, 0, 0, "Save?", "Yes", "No")
+	assert.True(t, yes)
+	s.Press(termbox.KeyEsc)
+	yes, ev, _ = Confirm(0, 0, 0, 0, "Save?", "Yes", "No")
+	assert.False(t, yes)
+	assert.Equal(t, ev.Key, termbox.KeyEsc)
+	x, y := s.Cursor()
+	assert.Equal(t, []int{x, y}, []int{-1, -1})
+
+	// Events are over
+	_, _, err = Confirm(0, 0, 0, 0, "", "Ok")
+	assert.IsType(t, &TerminalError{}, err)
+}
+
+func TestAttributeColor(t *testing.T) {
+	c, rgb, _, _, _ := AttributeColor(termbox.ColorRed | termbox.AttrBold)
+	assert.Equal(t, c, int(termbox.ColorRed))
+	assert.False(t, rgb)
+	c, _, _, _, _ = AttributeColor(termbox.Attribute(256))
+	assert.Equal(t, c, 256)
+	_, rgb, r, g, b := AttributeColor(termbox.RGBToAttribute(1, 2, 3))
+	assert.True(t, rgb)
+	assert.Equal(t, []uint8{r, g, b}, []uint8{1, 2, 3})
+}
+
+type mouseTestScreen struct {
+	*MemoryScreen
+	mouse bool
+}
+
+func (s *mouseTestScreen) EnableMouse() {
+	s.mouse = true
+}
+
+func TestEnableMouse(t *testing.T) {
+	// termbox is not initialized
+	useTestScreen(t, TermboxScreen{})
+	EnableMouse()
+	SetScreen(NewMemoryScreen(10, 1))
+	EnableMouse()
+	s := &mouseTestScreen{MemoryScreen: NewMemoryScreen(10, 1)}
+	SetScreen(s)
+	EnableMouse()
+	assert.True(t, s.mouse)
+}
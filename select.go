@@ -1,6 +1,7 @@
 package editbox
 
 import (
+	"context"
 	"github.com/nsf/termbox-go"
 )
 
@@ -13,6 +14,8 @@ type SelectBox struct {
 	width, height int
 	fg, bg        termbox.Attribute
 	sfg, sbg      termbox.Attribute
+	onResize      ResizeFunc
+	blurred       bool
 }
 
 func (sbox *SelectBox) scrollToCursor() {
@@ -59,6 +62,17 @@ func (sbox *SelectBox) SelectedIndex() int {
 	return sbox.selectable[sbox.cursor]
 }
 
+// Selects item at index. Does nothing if item is not selectable.
+func (sbox *SelectBox) SetSelectedIndex(index int) {
+	for i, selectable := range sbox.selectable {
+		if selectable == index {
+			sbox.cursor = i
+			sbox.scrollToCursor()
+			return
+		}
+	}
+}
+
 func (sbox *SelectBox) Text() string {
 	return sbox.items[sbox.SelectedIndex()]
 }
@@ -71,7 +85,9 @@ func (sbox *SelectBox) Render() {
 		if index > len(sbox.items)-1 {
 			break
 		}
-		if index == sbox.SelectedIndex() {
+		if index == sbox.SelectedIndex() && sbox.blurred {
+			fg, bg = sbox.fg|termbox.AttrReverse, sbox.bg
+		} else if index == sbox.SelectedIndex() {
 			fg, bg = sbox.sfg, sbox.sbg
 		} else {
 			fg, bg = sbox.fg, sbox.bg
@@ -84,6 +100,10 @@ func (sbox *SelectBox) Render() {
 // Useful if you poll them by yourself.
 // Returns false on unknown event.
 func (sbox *SelectBox) HandleEvent(ev termbox.Event) bool {
+	if ev.Type == termbox.EventResize {
+		sbox.handleResize(ev)
+		return true
+	}
 	if ev.Type != termbox.EventKey {
 		return false
 	}
@@ -104,36 +124,31 @@ func (sbox *SelectBox) HandleEvent(ev termbox.Event) bool {
 
 // Make widget to listen for termbox events
 // Blocks until exit event.
-// Returns event which made SelectBox to exit, selected index,
-// and selected value
-func (sbox *SelectBox) WaitExit() termbox.Event {
-	sbox.Render()
-	termbox.Flush()
-	for {
-		ev := termbox.PollEvent()
-		if ev.Type == termbox.EventError {
-			panic(ev.Err)
-		}
-		if !sbox.HandleEvent(ev) && ev.Type == termbox.EventKey {
-			switch {
-			case ev.Key == termbox.KeyEnter:
-				return ev
-			case ev.Key == termbox.KeyEsc || ev.Key == termbox.KeyTab:
-				return ev
-			default:
-				// do nothing
-			}
-		}
-		sbox.Render()
-		termbox.Flush()
-	}
+// Returns event which made SelectBox to exit.
+// Returns *TerminalError on termbox error event.
+func (sbox *SelectBox) WaitExit() (termbox.Event, error) {
+	return sbox.WaitExitContext(context.Background())
+}
+
+// Same as WaitExit but also returns ctx.Err() when ctx is cancelled
+// or its deadline is exceeded.
+func (sbox *SelectBox) WaitExitContext(ctx context.Context) (termbox.Event, error) {
+	return waitExit(ctx, sbox.isExit,
+		func(ev termbox.Event) { sbox.HandleEvent(ev) }, sbox.Render)
+}
+
+// Returns true if event makes WaitExit return
+func (sbox *SelectBox) isExit(ev termbox.Event) bool {
+	return ev.Type == termbox.EventKey && ev.Ch == 0 &&
+		(ev.Key == termbox.KeyEnter || ev.Key == termbox.KeyEsc ||
+			ev.Key == termbox.KeyTab)
 }
 
 //----------------------------------------------------------------------------
 // Widgets
 //----------------------------------------------------------------------------
 
-// Create new Select widget. This DOES NOT call termbox.Flush().
+// Create new Select widget. This DOES NOT flush screen.
 func Select(
 	x, y, width, height int,
 	fg, bg, sfg, sbg termbox.Attribute,
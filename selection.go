@@ -0,0 +1,271 @@
+package editbox
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// Clipboard stores text copied or cut by widgets. Implement it to
+// integrate with system clipboard. Methods may be called concurrently.
+type Clipboard interface {
+	// Returns text inserted on paste
+	Get() string
+	// Stores copied or cut text
+	Set(s string)
+}
+
+// In-memory Clipboard safe for concurrent use.
+// Zero value is empty clipboard.
+type MemoryClipboard struct {
+	mu   sync.Mutex
+	text string
+}
+
+func (c *MemoryClipboard) Get() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.text
+}
+
+func (c *MemoryClipboard) Set(s string) {
+	c.mu.Lock()
+	c.text = s
+	c.mu.Unlock()
+}
+
+// Clipboard shared by widgets without own clipboard
+var sharedClipboard = &MemoryClipboard{}
+
+// Returns text of clipboard shared by widgets.
+func ClipboardText() string {
+	return sharedClipboard.Get()
+}
+
+// Sets text of clipboard shared by widgets.
+func SetClipboardText(s string) {
+	sharedClipboard.Set(s)
+}
+
+// Sets clipboard used by widget instead of shared one.
+// nil restores shared clipboard.
+func (ebox *Editbox) SetClipboard(c Clipboard) {
+	ebox.clipboard = c
+}
+
+func (ebox *Editbox) getClipboard() Clipboard {
+	if ebox.clipboard == nil {
+		return sharedClipboard
+	}
+	return ebox.clipboard
+}
+
+//----------------------------------------------------------------------------
+// Selection in editor
+//----------------------------------------------------------------------------
+
+// Text between anchor and cursor is selected
+func (ed *editor) startSelection() {
+	ed.selecting = true
+	ed.block = false
+	ed.anchor = ed.cursor
+}
+
+func (ed *editor) clearSelection() {
+	ed.selecting = false
+	ed.block = false
+}
+
+// Returns ordered selection bounds. End is exclusive.
+// Returns false if there is no selection or selection is empty.
+func (ed *editor) selectionRange() (start, end cursor, ok bool) {
+	if !ed.selecting || ed.anchor == ed.cursor {
+		return
+	}
+	start, end = orderedRange(ed.anchor, ed.cursor)
+	return start, end, true
+}
+
+func orderedRange(a, b cursor) (cursor, cursor) {
+	if b.y < a.y || (b.y == a.y && b.x < a.x) {
+		return b, a
+	}
+	return a, b
+}
+
+// Returns true if x, y is between start (inclusive) and end (exclusive)
+func inRange(start, end cursor, x, y int) bool {
+	if y < start.y || y > end.y {
+		return false
+	}
+	if y == start.y && x < start.x {
+		return false
+	}
+	if y == end.y && x >= end.x {
+		return false
+	}
+	return true
+}
+
+func (ed *editor) isSelected(x, y int) bool {
+	if ed.block {
+		return ed.isBlockSelected(x, y)
+	}
+	if start, end, ok := ed.selectionRange(); ok && inRange(start, end, x, y) {
+		return true
+	}
+	return ed.isSelectedByCaret(x, y)
+}
+
+func (ed *editor) textRange(start, end cursor) string {
+	var b bytes.Buffer
+	for y := start.y; y <= end.y; y++ {
+		text := ed.lines[y].text
+		x1, x2 := 0, len(text)
+		if y == start.y {
+			x1 = start.x
+		}
+		if y == end.y {
+			x2 = end.x
+		}
+		b.WriteString(string(text[x1:x2]))
+	}
+	return b.String()
+}
+
+func (ed *editor) selectedText() string {
+	if ed.block {
+		return ed.blockText()
+	}
+	start, end, ok := ed.selectionRange()
+	if !ok {
+		return ""
+	}
+	return ed.textRange(start, end)
+}
+
+// Deletes text between start and end and places cursor at start
+func (ed *editor) deleteRange(start, end cursor) {
+	text := ed.textRange(start, end)
+	ed.cursor = start
+	muted := ed.muted
+	ed.muted = true
+	for range text {
+		ed.deleteRuneAtCursor()
+	}
+	ed.muted = muted
+	ed.lastx = ed.cursor.x
+	ed.notify(Change{
+		Start:   Position{Line: start.y, Col: start.x},
+		End:     Position{Line: end.y, Col: end.x},
+		Deleted: text,
+	})
+}
+
+// Deletes selected text and clears selection.
+// Block selection is not cleared but collapsed to zero width.
+// Returns false if nothing was deleted.
+func (ed *editor) deleteSelection() bool {
+	if ed.block {
+		return ed.deleteBlock()
+	}
+	start, end, ok := ed.selectionRange()
+	ed.clearSelection()
+	if !ok {
+		return false
+	}
+	ed.deleteRange(start, end)
+	return true
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Starts selection at cursor position or cancels it if selection
+// has already started. Cursor movement extends selection.
+func (ebox *Editbox) ToggleSelection() {
+	if ebox.editor.selecting {
+		ebox.editor.clearSelection()
+	} else {
+		ebox.editor.startSelection()
+	}
+}
+
+// Selects whole widget content.
+func (ebox *Editbox) SelectAll() {
+	ed := ebox.editor
+	ed.clearCarets()
+	ed.cursor = cursor{0, 0}
+	ed.startSelection()
+	ed.cursor.y = len(ed.lines) - 1
+	ed.cursor.x = len(ed.currentLine().text)
+	ed.lastx = ed.cursor.x
+}
+
+// Returns selected text.
+func (ebox *Editbox) SelectedText() string {
+	return ebox.editor.selectedText()
+}
+
+// Copies selected text to clipboard and clears selection.
+// Selections of several cursors are copied as lines in text order.
+func (ebox *Editbox) Copy() {
+	ed := ebox.editor
+	if s := strings.Join(ed.selectedTexts(), "\n"); strings.Trim(s, "\n") != "" &&
+		ebox.mask == 0 {
+		ebox.getClipboard().Set(s)
+	}
+	ed.forEachCursor(ed.clearSelection)
+}
+
+// Moves selected text to clipboard.
+// Selections of several cursors are moved as lines in text order.
+// Does nothing in read-only mode.
+func (ebox *Editbox) Cut() {
+	if ebox.readOnly {
+		return
+	}
+	ed := ebox.editor
+	if s := strings.Join(ed.selectedTexts(), "\n"); strings.Trim(s, "\n") != "" &&
+		ebox.mask == 0 {
+		ebox.getClipboard().Set(s)
+	}
+	ed.beginUndo()
+	defer ed.endUndo()
+	ed.forEachCursor(func() {
+		ed.deleteSelection()
+	})
+}
+
+// Replaces selection with clipboard content at every cursor.
+// If clipboard has as many lines as there are cursors, every cursor
+// gets its own line.
+// Does nothing in read-only mode.
+func (ebox *Editbox) Paste() {
+	if ebox.readOnly {
+		return
+	}
+	text := ebox.getClipboard().Get()
+	ed := ebox.editor
+	n := len(ed.carets) + 1
+	parts := strings.Split(text, "\n")
+	ed.beginUndo()
+	defer ed.endUndo()
+	// forEachCursor goes from the last cursor to the first one
+	i := n
+	ed.forEachCursor(func() {
+		i--
+		s := text
+		if n > 1 && len(parts) == n {
+			s = parts[i]
+		}
+		ed.deleteSelection()
+		if ed.block && !strings.ContainsRune(s, '\n') {
+			ed.insertBlock(s)
+			return
+		}
+		ed.clearSelection()
+		ed.setText(s)
+	})
+}
@@ -0,0 +1,107 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEditorSelection(t *testing.T) {
+	ed := newEditor()
+	ed.setText("123\n456\n789")
+	ed.cursor = cursor{2, 1}
+	ed.startSelection()
+	ed.moveCursorLeft()
+	ed.moveCursorLeft()
+	ed.moveCursorLeft()
+	start, end, ok := ed.selectionRange()
+	assert.True(t, ok)
+	assert.Equal(t, start, cursor{3, 0})
+	assert.Equal(t, end, cursor{2, 1})
+	assert.Equal(t, ed.selectedText(), "\n45")
+	assert.True(t, ed.isSelected(3, 0))
+	assert.True(t, ed.isSelected(1, 1))
+	assert.False(t, ed.isSelected(2, 1))
+	assert.False(t, ed.isSelected(2, 0))
+	assert.True(t, ed.deleteSelection())
+	assert.Equal(t, ed.text(), "1236\n789")
+	assert.Equal(t, ed.cursor, cursor{3, 0})
+	assert.False(t, ed.deleteSelection())
+}
+
+func TestEditorDeleteSelectionOnChange(t *testing.T) {
+	ed := newEditor()
+	ed.setText("123\n456")
+	changes := []Change{}
+	ed.onChange = func(c Change) {
+		changes = append(changes, c)
+	}
+	ed.cursor = cursor{1, 0}
+	ed.startSelection()
+	ed.cursor = cursor{1, 1}
+	ed.deleteSelection()
+	assert.Equal(t, ed.text(), "156")
+	assert.Equal(t, changes, []Change{{
+		Start:   Position{0, 1},
+		End:     Position{1, 1},
+		Deleted: "23\n4",
+	}})
+}
+
+func TestCopyPaste(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("foo bar")
+	eb.updateLineOffsets()
+	sendKey(eb, termbox.KeyHome)
+	sendKey(eb, termbox.KeyCtrlSpace)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	sendKey(eb, termbox.KeyArrowRight)
+	assert.Equal(t, eb.SelectedText(), "foo")
+	sendKey(eb, termbox.KeyCtrlC)
+	assert.Equal(t, ClipboardText(), "foo")
+	assert.Equal(t, eb.SelectedText(), "")
+	sendKey(eb, termbox.KeyEnd)
+	sendKey(eb, termbox.KeyCtrlV)
+	assert.Equal(t, eb.Text(), "foo barfoo")
+
+	eb.SelectAll()
+	sendString(eb, "x")
+	assert.Equal(t, eb.Text(), "x")
+}
+
+func TestWidgetClipboard(t *testing.T) {
+	SetClipboardText("shared")
+	var clip MemoryClipboard
+	eb := newEditbox(0, 0, 10, 1, options{})
+	eb.SetClipboard(&clip)
+	eb.SetText("foo")
+	eb.SelectAll()
+	eb.Copy()
+	assert.Equal(t, clip.Get(), "foo")
+	assert.Equal(t, ClipboardText(), "shared")
+	eb.SetClipboard(nil)
+	eb.Paste()
+	assert.Equal(t, eb.Text(), "fooshared")
+}
+
+func TestReadOnly(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("foo\nbar")
+	eb.SetReadOnly(true)
+	eb.updateLineOffsets()
+	sendString(eb, "x")
+	sendKey(eb, termbox.KeyEnter)
+	sendKey(eb, termbox.KeyBackspace)
+	sendKey(eb, termbox.KeyArrowUp)
+	sendKey(eb, termbox.KeyDelete)
+	assert.Equal(t, eb.Text(), "foo\nbar")
+	assert.Equal(t, eb.editor.cursor, cursor{3, 0})
+
+	sendKey(eb, termbox.KeyCtrlA)
+	sendKey(eb, termbox.KeyCtrlX)
+	sendKey(eb, termbox.KeyCtrlV)
+	assert.Equal(t, eb.Text(), "foo\nbar")
+	sendKey(eb, termbox.KeyCtrlC)
+	assert.Equal(t, ClipboardText(), "foo\nbar")
+}
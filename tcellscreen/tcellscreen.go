@@ -0,0 +1,273 @@
+// Package tcellscreen runs editbox widgets on tcell instead of termbox.
+//
+//	s, err := tcellscreen.Init()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer s.Close()
+//	input := editbox.Input(0, 0, 20, termbox.ColorWhite, termbox.ColorBlue)
+//	input.WaitExit()
+//
+// tcell keys, mouse buttons, colors and attributes are converted to
+// termbox ones, so widgets and forms work unchanged. Alt combinations
+// are always reported. Shift+Tab is reported as KeyBacktab, Shift and
+// Ctrl of other keys are dropped because termbox and widgets have no keys
+// for them. Line breaks of bracketed paste are reported as '\n' runes, so
+// pasted text does not submit forms. RGB colors of termbox.RGBToAttribute
+// are shown in true color.
+package tcellscreen
+
+import (
+	"errors"
+	"github.com/gdamore/tcell/v2"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+)
+
+// Shift+Tab, same as editbox.KeyBacktab. Form moves focus back on it.
+const KeyBacktab = editbox.KeyBacktab
+
+// Returned in termbox.EventError after screen is closed
+var ErrClosed = errors.New("tcellscreen: screen closed")
+
+// tcell keys which have termbox counterparts.
+// Control keys have the same codes in both libraries.
+var keys = map[tcell.Key]termbox.Key{
+	tcell.KeyUp:      termbox.KeyArrowUp,
+	tcell.KeyDown:    termbox.KeyArrowDown,
+	tcell.KeyLeft:    termbox.KeyArrowLeft,
+	tcell.KeyRight:   termbox.KeyArrowRight,
+	tcell.KeyPgUp:    termbox.KeyPgup,
+	tcell.KeyPgDn:    termbox.KeyPgdn,
+	tcell.KeyHome:    termbox.KeyHome,
+	tcell.KeyEnd:     termbox.KeyEnd,
+	tcell.KeyInsert:  termbox.KeyInsert,
+	tcell.KeyDelete:  termbox.KeyDelete,
+	tcell.KeyBacktab: KeyBacktab,
+	tcell.KeyF1:      termbox.KeyF1,
+	tcell.KeyF2:      termbox.KeyF2,
+	tcell.KeyF3:      termbox.KeyF3,
+	tcell.KeyF4:      termbox.KeyF4,
+	tcell.KeyF5:      termbox.KeyF5,
+	tcell.KeyF6:      termbox.KeyF6,
+	tcell.KeyF7:      termbox.KeyF7,
+	tcell.KeyF8:      termbox.KeyF8,
+	tcell.KeyF9:      termbox.KeyF9,
+	tcell.KeyF10:     termbox.KeyF10,
+	tcell.KeyF11:     termbox.KeyF11,
+	tcell.KeyF12:     termbox.KeyF12,
+}
+
+// Implements editbox.Screen on tcell
+type Screen struct {
+	screen tcell.Screen
+	// Mouse buttons of previous mouse event and its position
+	buttons tcell.ButtonMask
+	x, y    int
+	// Inside of bracketed paste
+	pasting bool
+}
+
+// Creates and initializes tcell screen with bracketed paste and makes
+// editbox widgets draw on it and read events from it. Call Close when done.
+func Init() (*Screen, error) {
+	ts, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+	if err = ts.Init(); err != nil {
+		return nil, err
+	}
+	ts.EnablePaste()
+	s := New(ts)
+	editbox.SetScreen(s)
+	return s, nil
+}
+
+// Wraps initialized tcell screen, e.g. tcell.SimulationScreen.
+// This DOES NOT make widgets use it, see editbox.SetScreen.
+func New(ts tcell.Screen) *Screen {
+	return &Screen{screen: ts}
+}
+
+// Finalizes tcell screen and makes widgets use termbox again.
+func (s *Screen) Close() {
+	s.screen.Fini()
+	editbox.SetScreen(editbox.TermboxScreen{})
+}
+
+// Returns wrapped tcell screen
+func (s *Screen) Tcell() tcell.Screen {
+	return s.screen
+}
+
+// Enables mouse events. Implements editbox.MouseScreen, so
+// editbox.EnableMouse calls it when screen is current.
+func (s *Screen) EnableMouse() {
+	s.screen.EnableMouse()
+}
+
+func (s *Screen) SetCell(x, y int, ch rune, fg, bg termbox.Attribute) {
+	s.screen.SetContent(x, y, ch, nil, Style(fg, bg))
+}
+
+func (s *Screen) SetCursor(x, y int) {
+	if x < 0 || y < 0 {
+		s.screen.HideCursor()
+		return
+	}
+	s.screen.ShowCursor(x, y)
+}
+
+func (s *Screen) Size() (width, height int) {
+	return s.screen.Size()
+}
+
+func (s *Screen) Clear(fg, bg termbox.Attribute) {
+	s.screen.Fill(' ', Style(fg, bg))
+}
+
+func (s *Screen) Flush() error {
+	s.screen.Show()
+	return nil
+}
+
+// Returns next tcell event converted to termbox event. Events without
+// termbox counterpart, like focus or paste marks, are skipped.
+func (s *Screen) PollEvent() termbox.Event {
+	for {
+		if ev, ok := s.convert(s.screen.PollEvent()); ok {
+			return ev
+		}
+	}
+}
+
+func (s *Screen) Interrupt() {
+	s.screen.PostEventWait(tcell.NewEventInterrupt(nil))
+}
+
+// Returns false if event has no termbox counterpart
+func (s *Screen) convert(ev tcell.Event) (termbox.Event, bool) {
+	switch ev := ev.(type) {
+	case nil:
+		// tcell returns nil after Fini
+		return termbox.Event{Type: termbox.EventError, Err: ErrClosed}, true
+	case *tcell.EventKey:
+		tev, ok := convertKey(ev)
+		// Pasted line breaks are text, not Enter
+		if s.pasting && tev.Ch == 0 && tev.Mod == 0 &&
+			(tev.Key == termbox.KeyEnter || tev.Key == termbox.KeyCtrlJ) {
+			tev.Key, tev.Ch = 0, '\n'
+		}
+		return tev, ok
+	case *tcell.EventPaste:
+		s.pasting = ev.Start()
+		return termbox.Event{}, false
+	case *tcell.EventMouse:
+		return s.convertMouse(ev)
+	case *tcell.EventResize:
+		width, height := ev.Size()
+		return termbox.Event{
+			Type: termbox.EventResize, Width: width, Height: height,
+		}, true
+	case *tcell.EventInterrupt:
+		return termbox.Event{Type: termbox.EventInterrupt}, true
+	case *tcell.EventError:
+		return termbox.Event{Type: termbox.EventError, Err: ev}, true
+	}
+	return termbox.Event{}, false
+}
+
+func convertKey(ev *tcell.EventKey) (termbox.Event, bool) {
+	tev := termbox.Event{Type: termbox.EventKey}
+	if ev.Modifiers()&tcell.ModAlt != 0 {
+		tev.Mod = termbox.ModAlt
+	}
+	switch key := ev.Key(); {
+	case key == tcell.KeyRune && ev.Rune() == ' ':
+		// termbox reports space as key
+		tev.Key = termbox.KeySpace
+	case key == tcell.KeyRune:
+		tev.Ch = ev.Rune()
+	case key <= tcell.KeyDEL:
+		tev.Key = termbox.Key(key)
+	default:
+		var ok bool
+		if tev.Key, ok = keys[key]; !ok {
+			return tev, false
+		}
+	}
+	return tev, true
+}
+
+// termbox reports pressed button once, then motion with ModMotion
+// and release. tcell reports pressed buttons in every event.
+func (s *Screen) convertMouse(ev *tcell.EventMouse) (termbox.Event, bool) {
+	x, y := ev.Position()
+	tev := termbox.Event{Type: termbox.EventMouse, MouseX: x, MouseY: y}
+	buttons := ev.Buttons()
+	pressed := buttons & (tcell.Button1 | tcell.Button2 | tcell.Button3)
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		tev.Key = termbox.MouseWheelUp
+		return tev, true
+	case buttons&tcell.WheelDown != 0:
+		tev.Key = termbox.MouseWheelDown
+		return tev, true
+	case pressed&tcell.Button1 != 0:
+		tev.Key = termbox.MouseLeft
+	case pressed&tcell.Button3 != 0:
+		tev.Key = termbox.MouseMiddle
+	case pressed&tcell.Button2 != 0:
+		tev.Key = termbox.MouseRight
+	case s.buttons != 0:
+		tev.Key = termbox.MouseRelease
+	default:
+		// Motion without buttons
+		return tev, false
+	}
+	if pressed != 0 && pressed == s.buttons {
+		if x == s.x && y == s.y {
+			return tev, false
+		}
+		tev.Mod = termbox.ModMotion
+	}
+	// termbox does not report modifiers of mouse events,
+	// Alt is passed for Alt-drag block selection
+	if ev.Modifiers()&tcell.ModAlt != 0 {
+		tev.Mod |= termbox.ModAlt
+	}
+	s.buttons, s.x, s.y = pressed, x, y
+	return tev, true
+}
+
+// Converts termbox colors and attributes to tcell style. Attributes
+// are taken from fg like termbox does, reverse is taken from both.
+func Style(fg, bg termbox.Attribute) tcell.Style {
+	style := tcell.StyleDefault.
+		Background(Color(bg)).
+		Bold(fg&termbox.AttrBold != 0).
+		Blink(fg&termbox.AttrBlink != 0).
+		Dim(fg&termbox.AttrDim != 0).
+		Italic(fg&termbox.AttrCursive != 0).
+		Underline(fg&termbox.AttrUnderline != 0).
+		Reverse((fg|bg)&termbox.AttrReverse != 0)
+	// tcell has no hidden attribute
+	if fg&termbox.AttrHidden != 0 {
+		return style.Foreground(Color(bg))
+	}
+	return style.Foreground(Color(fg))
+}
+
+// Converts color of termbox attribute to tcell color. termbox colors
+// are numbered from 1, ColorDefault is 0.
+func Color(attr termbox.Attribute) tcell.Color {
+	c, rgb, r, g, b := editbox.AttributeColor(attr)
+	switch {
+	case rgb:
+		return tcell.NewRGBColor(int32(r), int32(g), int32(b))
+	case c == int(termbox.ColorDefault):
+		return tcell.ColorDefault
+	}
+	return tcell.PaletteColor(c - 1)
+}
@@ -0,0 +1,153 @@
+package tcellscreen
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/nsf/termbox-go"
+	"github.com/smetana/editbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestScreen(t *testing.T) (*Screen, tcell.SimulationScreen) {
+	sim := tcell.NewSimulationScreen("")
+	if err := sim.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sim.SetSize(20, 3)
+	t.Cleanup(sim.Fini)
+	return New(sim), sim
+}
+
+func keyEvent(key tcell.Key, ch rune, mod tcell.ModMask) termbox.Event {
+	ev, _ := convertKey(tcell.NewEventKey(key, ch, mod))
+	return ev
+}
+
+func TestConvertKey(t *testing.T) {
+	assert.Equal(t, keyEvent(tcell.KeyRune, 'a', 0),
+		termbox.Event{Type: termbox.EventKey, Ch: 'a'})
+	assert.Equal(t, keyEvent(tcell.KeyRune, ' ', 0),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeySpace})
+	assert.Equal(t, keyEvent(tcell.KeyRune, 'b', tcell.ModAlt),
+		termbox.Event{Type: termbox.EventKey, Ch: 'b', Mod: termbox.ModAlt})
+	assert.Equal(t, keyEvent(tcell.KeyEnter, 0, 0),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter})
+	assert.Equal(t, keyEvent(tcell.KeyCtrlA, 0, tcell.ModCtrl),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeyCtrlA})
+	assert.Equal(t, keyEvent(tcell.KeyBackspace2, 0, 0),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeyBackspace2})
+	assert.Equal(t, keyEvent(tcell.KeyLeft, 0, tcell.ModShift),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeyArrowLeft})
+	assert.Equal(t, keyEvent(tcell.KeyBacktab, 0, 0),
+		termbox.Event{Type: termbox.EventKey, Key: KeyBacktab})
+	_, ok := convertKey(tcell.NewEventKey(tcell.KeyF20, 0, 0))
+	assert.False(t, ok)
+}
+
+func TestConvertMouse(t *testing.T) {
+	s, _ := newTestScreen(t)
+	mouse := func(x, y int, buttons tcell.ButtonMask) (termbox.Event, bool) {
+		return s.convertMouse(tcell.NewEventMouse(x, y, buttons, 0))
+	}
+	_, ok := mouse(1, 1, tcell.ButtonNone)
+	assert.False(t, ok)
+
+	ev, ok := mouse(1, 1, tcell.Button1)
+	assert.True(t, ok)
+	assert.Equal(t, ev, termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 1, MouseY: 1,
+	})
+	_, ok = mouse(1, 1, tcell.Button1)
+	assert.False(t, ok)
+	ev, _ = mouse(3, 1, tcell.Button1)
+	assert.Equal(t, ev.Key, termbox.MouseLeft)
+	assert.Equal(t, ev.Mod, termbox.ModMotion)
+	ev, _ = mouse(3, 2, tcell.ButtonNone)
+	assert.Equal(t, ev.Key, termbox.MouseRelease)
+	assert.Equal(t, ev.Mod, termbox.Modifier(0))
+	_, ok = mouse(4, 2, tcell.ButtonNone)
+	assert.False(t, ok)
+
+	ev, _ = mouse(0, 0, tcell.Button2)
+	assert.Equal(t, ev.Key, termbox.MouseRight)
+	ev, _ = mouse(0, 0, tcell.WheelDown)
+	assert.Equal(t, ev.Key, termbox.MouseWheelDown)
+	mouse(0, 0, tcell.ButtonNone)
+
+	// Alt-drag
+	ev, _ = s.convertMouse(tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModAlt))
+	assert.Equal(t, ev.Mod, termbox.ModAlt)
+	ev, _ = s.convertMouse(tcell.NewEventMouse(2, 0, tcell.Button1, tcell.ModAlt))
+	assert.Equal(t, ev.Mod, termbox.ModMotion|termbox.ModAlt)
+}
+
+func TestConvertPaste(t *testing.T) {
+	s, _ := newTestScreen(t)
+	convert := func(ev tcell.Event) termbox.Event {
+		tev, _ := s.convert(ev)
+		return tev
+	}
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, 0)
+	_, ok := s.convert(tcell.NewEventPaste(true))
+	assert.False(t, ok)
+	assert.Equal(t, convert(tcell.NewEventKey(tcell.KeyRune, 'a', 0)),
+		termbox.Event{Type: termbox.EventKey, Ch: 'a'})
+	assert.Equal(t, convert(enter), termbox.Event{Type: termbox.EventKey, Ch: '\n'})
+	assert.Equal(t, convert(tcell.NewEventKey(tcell.KeyLF, 0, 0)),
+		termbox.Event{Type: termbox.EventKey, Ch: '\n'})
+	_, ok = s.convert(tcell.NewEventPaste(false))
+	assert.False(t, ok)
+	assert.Equal(t, convert(enter),
+		termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter})
+}
+
+func TestEnableMouse(t *testing.T) {
+	s, _ := newTestScreen(t)
+	assert.Implements(t, (*editbox.MouseScreen)(nil), s)
+}
+
+func TestStyle(t *testing.T) {
+	fg, bg, attrs := Style(termbox.ColorRed|termbox.AttrBold|termbox.AttrReverse,
+		termbox.ColorDefault).Decompose()
+	assert.Equal(t, fg, tcell.ColorMaroon)
+	assert.Equal(t, bg, tcell.ColorDefault)
+	assert.Equal(t, attrs, tcell.AttrBold|tcell.AttrReverse)
+
+	assert.Equal(t, Color(termbox.ColorLightGray), tcell.ColorWhite)
+	assert.Equal(t, Color(termbox.Attribute(256)), tcell.PaletteColor(255))
+	assert.Equal(t, Color(termbox.RGBToAttribute(1, 2, 3)),
+		tcell.NewRGBColor(1, 2, 3))
+
+	fg, _, _ = Style(termbox.ColorRed|termbox.AttrHidden, termbox.ColorBlue).
+		Decompose()
+	assert.Equal(t, fg, tcell.ColorNavy)
+}
+
+func TestWaitExit(t *testing.T) {
+	s, sim := newTestScreen(t)
+	editbox.SetScreen(s)
+	defer editbox.SetScreen(editbox.TermboxScreen{})
+	input := editbox.Input(1, 1, 10, termbox.ColorWhite, termbox.ColorBlue)
+	for _, r := range "hi" {
+		sim.InjectKey(tcell.KeyRune, r, 0)
+	}
+	sim.InjectKey(tcell.KeyEnter, 0, 0)
+	ev, err := input.WaitExit()
+	assert.Nil(t, err)
+	assert.Equal(t, ev.Key, termbox.KeyEnter)
+	assert.Equal(t, input.Text(), "hi")
+
+	cells, width, _ := sim.GetContents()
+	cell := cells[width+1]
+	assert.Equal(t, string(cell.Runes), "h")
+	assert.Equal(t, cell.Style, Style(termbox.ColorWhite, termbox.ColorBlue))
+	x, y, visible := sim.GetCursor()
+	assert.Equal(t, []int{x, y}, []int{3, 1})
+	assert.True(t, visible)
+}
+
+func TestInterrupt(t *testing.T) {
+	s, _ := newTestScreen(t)
+	go s.Interrupt()
+	assert.Equal(t, s.PollEvent().Type, termbox.EventInterrupt)
+}
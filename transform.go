@@ -0,0 +1,149 @@
+package editbox
+
+import (
+	"strings"
+	"unicode"
+)
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Returns bounds of word under cursor or word which ends at cursor.
+// Returns false if there is no word at cursor.
+func (ed *editor) wordAt(c cursor) (start, end cursor, ok bool) {
+	text := ed.lines[c.y].text
+	x := c.x
+	if x >= len(text) || !isWordRune(text[x]) {
+		if x == 0 || !isWordRune(text[x-1]) {
+			return
+		}
+		x--
+	}
+	x1, x2 := x, x
+	for x1 > 0 && isWordRune(text[x1-1]) {
+		x1--
+	}
+	for x2 < len(text) && isWordRune(text[x2]) {
+		x2++
+	}
+	return cursor{x1, c.y}, cursor{x2, c.y}, true
+}
+
+// Replaces text between start and end with s as single change
+func (ed *editor) replaceRange(start, end cursor, s string) {
+	deleted := ed.textRange(start, end)
+	muted := ed.muted
+	ed.muted = true
+	ed.deleteRange(start, end)
+	ed.setText(s)
+	ed.muted = muted
+	ed.notify(Change{
+		Start:    Position{Line: start.y, Col: start.x},
+		End:      ed.position(),
+		Inserted: s,
+		Deleted:  deleted,
+	})
+}
+
+// Applies f to text between start and end and selects result
+func (ed *editor) transformRange(start, end cursor, f func(string) string) {
+	ed.replaceRange(start, end, f(ed.textRange(start, end)))
+	ed.anchor = start
+	ed.selecting = true
+}
+
+func (ed *editor) transformBlock(f func(string) string) {
+	top, bottom, left, right := ed.blockRange()
+	cursorY, anchor, lastx := ed.cursor.y, ed.anchor, ed.lastx
+	for y := top; y <= bottom; y++ {
+		x1, x2 := ed.blockColumns(y, left, right)
+		s := f(string(ed.lines[y].text[x1:x2]))
+		ed.replaceRange(cursor{x1, y}, cursor{x2, y}, s)
+	}
+	ed.anchor = anchor
+	ed.placeCursor(lastx, cursorY)
+}
+
+// Applies f to selection or to text returned by bounds if nothing
+// is selected
+func (ed *editor) transform(
+	f func(string) string,
+	bounds func() (cursor, cursor, bool),
+) {
+	if ed.block {
+		ed.transformBlock(f)
+		return
+	}
+	if start, end, ok := ed.selectionRange(); ok {
+		ed.transformRange(start, end, f)
+		return
+	}
+	start, end, ok := bounds()
+	if !ok {
+		return
+	}
+	// Keep cursor offset
+	offset := ed.offset(ed.cursor)
+	ed.replaceRange(start, end, f(ed.textRange(start, end)))
+	if offset < ed.length() {
+		ed.cursor = ed.cursorAt(offset)
+	} else {
+		ed.cursor = ed.endCursor()
+	}
+	ed.lastx = ed.cursor.x
+}
+
+func titleCase(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && isWordRune(runes[i-1]) {
+			runes[i] = unicode.ToLower(r)
+		} else {
+			runes[i] = unicode.ToTitle(r)
+		}
+	}
+	return string(runes)
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Replaces selection or whole content if nothing is selected with
+// result of f. Replacement is reported to OnChange as single change
+// and is reverted by single Undo. Rectangular selection is transformed
+// line by line.
+// Does nothing in read-only mode.
+func (ebox *Editbox) Transform(f func(string) string) {
+	ed := ebox.editor
+	ebox.editCommand(func() {
+		ed.transform(f, func() (cursor, cursor, bool) {
+			return cursor{0, 0}, ed.endCursor(), true
+		})
+	})
+}
+
+func (ebox *Editbox) transformWord(f func(string) string) {
+	ed := ebox.editor
+	ebox.editCommand(func() {
+		ed.transform(f, func() (cursor, cursor, bool) {
+			return ed.wordAt(ed.cursor)
+		})
+	})
+}
+
+// Converts selection or word under cursor to upper case.
+func (ebox *Editbox) UpperCase() {
+	ebox.transformWord(strings.ToUpper)
+}
+
+// Converts selection or word under cursor to lower case.
+func (ebox *Editbox) LowerCase() {
+	ebox.transformWord(strings.ToLower)
+}
+
+// Capitalizes every word in selection or word under cursor.
+func (ebox *Editbox) TitleCase() {
+	ebox.transformWord(titleCase)
+}
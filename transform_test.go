@@ -0,0 +1,74 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWordAt(t *testing.T) {
+	ed := newEditor()
+	ed.setText("foo bar_1, baz")
+	start, end, ok := ed.wordAt(cursor{5, 0})
+	assert.True(t, ok)
+	assert.Equal(t, start, cursor{4, 0})
+	assert.Equal(t, end, cursor{9, 0})
+	start, end, ok = ed.wordAt(cursor{3, 0}) // Word ends at cursor
+	assert.True(t, ok)
+	assert.Equal(t, start, cursor{0, 0})
+	assert.Equal(t, end, cursor{3, 0})
+	_, _, ok = ed.wordAt(cursor{10, 0})
+	assert.False(t, ok)
+}
+
+func TestCaseCommands(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("foo bar baz")
+	eb.SetCursor(5, 0)
+	sendAltKey(eb, 0, 'u')
+	assert.Equal(t, eb.Text(), "foo BAR baz")
+	assert.Equal(t, eb.editor.cursor, cursor{5, 0})
+	sendAltKey(eb, 0, 'c')
+	assert.Equal(t, eb.Text(), "foo Bar baz")
+	eb.SelectAll()
+	sendAltKey(eb, 0, 'u')
+	assert.Equal(t, eb.Text(), "FOO BAR BAZ")
+	assert.Equal(t, eb.SelectedText(), "FOO BAR BAZ")
+	sendAltKey(eb, 0, 'c')
+	assert.Equal(t, eb.Text(), "Foo Bar Baz")
+	sendAltKey(eb, 0, 'l')
+	assert.Equal(t, eb.Text(), "foo bar baz")
+}
+
+func TestTransform(t *testing.T) {
+	eb := newEditbox(0, 0, 20, 5, options{})
+	eb.SetText("a b\nc d")
+	changes := []Change{}
+	eb.OnChange(func(c Change) {
+		changes = append(changes, c)
+	})
+	eb.SetCursor(1, 1)
+	eb.Transform(func(s string) string {
+		return strings.Replace(s, " ", "\n", -1)
+	})
+	assert.Equal(t, eb.Text(), "a\nb\nc\nd")
+	assert.Equal(t, eb.editor.cursor, cursor{1, 2})
+	assert.Equal(t, changes, []Change{{
+		Start:    Position{0, 0},
+		End:      Position{3, 1},
+		Inserted: "a\nb\nc\nd",
+		Deleted:  "a b\nc d",
+	}})
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "a b\nc d")
+}
+
+func TestTransformBlock(t *testing.T) {
+	eb := newBlockTextarea("abc\nd\nefg", 1, 0, 2, 2)
+	sendKey(eb, termbox.KeyEnd)
+	eb.UpperCase()
+	assert.Equal(t, eb.Text(), "aBC\nd\neFG")
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "abc\nd\nefg")
+}
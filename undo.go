@@ -0,0 +1,96 @@
+package editbox
+
+// Max number of undo steps kept by editor
+const undoLimit = 1000
+
+// Changes between beginUndo and matching endUndo are undone together.
+// Calls may be nested, changes outside of them are undone one by one.
+func (ed *editor) beginUndo() {
+	ed.undoDepth++
+}
+
+func (ed *editor) endUndo() {
+	ed.undoDepth--
+	if ed.undoDepth == 0 {
+		ed.commitUndo()
+	}
+}
+
+// Records change reported by notify
+func (ed *editor) recordUndo(c Change) {
+	if ed.undoing {
+		return
+	}
+	ed.undoGroup = append(ed.undoGroup, c)
+	if ed.undoDepth == 0 {
+		ed.commitUndo()
+	}
+}
+
+func (ed *editor) commitUndo() {
+	if len(ed.undoGroup) == 0 {
+		return
+	}
+	ed.undo = append(ed.undo, ed.undoGroup)
+	ed.undoGroup = nil
+	if len(ed.undo) > undoLimit {
+		ed.undo = ed.undo[len(ed.undo)-undoLimit:]
+	}
+}
+
+// Drops all undo steps, e.g. when text is set from code
+func (ed *editor) clearUndo() {
+	ed.undo = nil
+	ed.undoGroup = nil
+}
+
+// Reverts the last undo step. Returns false if there is nothing to undo.
+func (ed *editor) undoLast() bool {
+	if len(ed.undo) == 0 {
+		return false
+	}
+	group := ed.undo[len(ed.undo)-1]
+	ed.undo = ed.undo[:len(ed.undo)-1]
+	ed.clearSelection()
+	ed.clearCarets()
+	ed.undoing = true
+	for i := len(group) - 1; i >= 0; i-- {
+		c := group[i]
+		start := cursor{c.Start.Col, c.Start.Line}
+		if c.Inserted != "" {
+			ed.deleteRange(start, cursor{c.End.Col, c.End.Line})
+		}
+		ed.cursor = start
+		ed.setText(c.Deleted)
+	}
+	ed.undoing = false
+	ed.lastx = ed.cursor.x
+	return true
+}
+
+// Runs line, reflow or transform command as single undo step
+// at primary cursor.
+func (ebox *Editbox) editCommand(cmd func()) {
+	if ebox.readOnly {
+		return
+	}
+	ebox.editor.clearCarets()
+	ebox.editor.beginUndo()
+	defer ebox.editor.endUndo()
+	cmd()
+}
+
+//----------------------------------------------------------------------------
+// API
+//----------------------------------------------------------------------------
+
+// Reverts the last edit. Typed rune, command like Transform or
+// DuplicateLine and edit of all cursors are undone as a whole.
+// Does nothing in read-only mode.
+func (ebox *Editbox) Undo() {
+	if ebox.readOnly {
+		return
+	}
+	defer ebox.cursorMoved(ebox.editor.cursor)
+	ebox.editor.undoLast()
+}
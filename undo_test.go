@@ -0,0 +1,74 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	sendString(eb, "ab")
+	sendKey(eb, termbox.KeyEnter)
+	sendString(eb, "cd")
+	sendKey(eb, termbox.KeyArrowLeft)
+	sendKey(eb, termbox.KeyBackspace2)
+	assert.Equal(t, eb.Text(), "ab\nd")
+	sendKey(eb, termbox.KeyCtrlZ)
+	assert.Equal(t, eb.Text(), "ab\ncd")
+	assert.Equal(t, eb.editor.cursor, cursor{1, 1})
+	sendKey(eb, termbox.KeyCtrlZ)
+	sendKey(eb, termbox.KeyCtrlZ)
+	sendKey(eb, termbox.KeyCtrlZ)
+	assert.Equal(t, eb.Text(), "ab")
+	eb.Undo()
+	eb.Undo()
+	eb.Undo() // Nothing to undo
+	assert.Equal(t, eb.Text(), "")
+}
+
+func TestUndoCommands(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	eb.SetText("foo\nbar")
+	eb.SetCursor(0, 0)
+	eb.DuplicateLine()
+	eb.SelectAll()
+	eb.Cut()
+	assert.Equal(t, eb.Text(), "")
+	eb.Paste()
+	eb.Paste()
+	assert.Equal(t, eb.Text(), "foo\nfoo\nbarfoo\nfoo\nbar")
+	eb.Undo()
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "")
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "foo\nfoo\nbar")
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "foo\nbar")
+	eb.Undo() // Text set by SetText is not undone
+	assert.Equal(t, eb.Text(), "foo\nbar")
+
+	// All cursors are undone at once
+	eb.SetCursor(0, 0)
+	eb.AddCursorBelow()
+	sendString(eb, "x")
+	assert.Equal(t, eb.Text(), "xfoo\nxbar")
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "foo\nbar")
+
+	eb.SetReadOnly(true)
+	eb.Undo()
+	assert.Equal(t, eb.Text(), "foo\nbar")
+}
+
+func TestUndoSetText(t *testing.T) {
+	eb := newEditbox(0, 0, 10, 3, options{})
+	sendString(eb, "a")
+	eb.SetText("initial")
+	sendKey(eb, termbox.KeyCtrlZ)
+	assert.Equal(t, eb.Text(), "ainitial")
+	sendString(eb, "x")
+	sendKey(eb, termbox.KeyCtrlZ)
+	sendKey(eb, termbox.KeyCtrlZ)
+	assert.Equal(t, eb.Text(), "ainitial")
+}
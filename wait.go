@@ -0,0 +1,155 @@
+package editbox
+
+import (
+	"context"
+	"github.com/nsf/termbox-go"
+)
+
+// Event loop of WaitExit. Polls event source in goroutine until
+// isExit returns true for event, termbox reports error or ctx is done.
+// Other events are passed to handle. Widget is rendered when there are
+// no more buffered events.
+// On cancellation polling goroutine is stopped with EventSource.Interrupt
+// before return, so caller may poll events again.
+func waitExit(
+	ctx context.Context,
+	isExit func(termbox.Event) bool,
+	handle func(termbox.Event),
+	render func(),
+) (termbox.Event, error) {
+	// Buffered channel processes paste from buffer faster
+	// because render is called less often
+	events := make(chan termbox.Event, 256)
+	exitEvent := make(chan termbox.Event)
+	p := newPoller()
+	go func() {
+		for {
+			ev := eventSource.PollEvent()
+			if ev.Type == termbox.EventInterrupt {
+				select {
+				case <-p.done:
+					close(p.stopped)
+					return
+				default:
+				}
+			}
+			// After cancellation events are dropped until interrupt
+			if ev.Type == termbox.EventError || isExit(ev) {
+				select {
+				case exitEvent <- ev:
+					return
+				case <-p.done:
+				}
+				continue
+			}
+			select {
+			case events <- ev:
+			case <-p.done:
+			}
+		}
+	}()
+	render()
+	screen.Flush()
+	for {
+		select {
+		case ev := <-events:
+			handle(ev)
+			// re-render on empty events buffer
+			if len(events) == 0 {
+				render()
+				screen.Flush()
+			}
+		case ev := <-exitEvent:
+			// Events polled before exit event may still be buffered
+			if len(events) > 0 {
+				for len(events) > 0 {
+					handle(<-events)
+				}
+				render()
+				screen.Flush()
+			}
+			if ev.Type == termbox.EventError {
+				return ev, &TerminalError{ev.Err}
+			}
+			return ev, nil
+		case <-ctx.Done():
+			p.stop()
+			return termbox.Event{Type: termbox.EventInterrupt}, ctx.Err()
+		}
+	}
+}
+
+// Stops goroutine blocked in EventSource.PollEvent on cancellation
+type poller struct {
+	// Closed on cancellation. Goroutine drops events until interrupt
+	// and closes stopped.
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newPoller() *poller {
+	return &poller{done: make(chan struct{}), stopped: make(chan struct{})}
+}
+
+// Interrupts polling goroutine and waits until it returns
+func (p *poller) stop() {
+	close(p.done)
+	eventSource.Interrupt()
+	<-p.stopped
+}
+
+// Event loop of Form.RunContext. Unlike waitExit events are polled one
+// by one on request of loop, so no event is taken from event source
+// after handle finishes loop. Returns event for which handle returned
+// true, error event as *TerminalError or ctx.Err() when ctx is done.
+func runLoop(
+	ctx context.Context,
+	handle func(termbox.Event) bool,
+	render func(),
+) (termbox.Event, error) {
+	next := make(chan struct{})
+	events := make(chan termbox.Event)
+	p := newPoller()
+	go func() {
+		defer close(p.stopped)
+		for {
+			select {
+			case <-next:
+			case <-p.done:
+				return
+			}
+			ev := eventSource.PollEvent()
+			select {
+			case events <- ev:
+				continue
+			case <-p.done:
+			}
+			// Cancelled while polling, drop events until interrupt
+			for ev.Type != termbox.EventInterrupt {
+				ev = eventSource.PollEvent()
+			}
+			return
+		}
+	}()
+	render()
+	screen.Flush()
+	for {
+		next <- struct{}{}
+		select {
+		case ev := <-events:
+			if ev.Type == termbox.EventError {
+				close(p.done)
+				return ev, &TerminalError{ev.Err}
+			}
+			if handle(ev) {
+				close(p.done)
+				return ev, nil
+			}
+			render()
+			screen.Flush()
+		case <-ctx.Done():
+			p.stop()
+			return termbox.Event{Type: termbox.EventInterrupt}, ctx.Err()
+		}
+	}
+}
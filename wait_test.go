@@ -0,0 +1,126 @@
+package editbox
+
+import (
+	"context"
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEditboxIsExit(t *testing.T) {
+	eb := newTestTextarea(0, 0, 10, 3)
+	key := func(key termbox.Key) termbox.Event {
+		return termbox.Event{Type: termbox.EventKey, Key: key}
+	}
+	assert.True(t, eb.isExit(key(termbox.KeyEsc)))
+	assert.True(t, eb.isExit(key(termbox.KeyTab)))
+	assert.False(t, eb.isExit(key(termbox.KeyEnter)))
+	assert.False(t, eb.isExit(termbox.Event{Type: termbox.EventKey, Ch: 'a'}))
+	assert.False(t, eb.isExit(termbox.Event{
+		Type: termbox.EventKey, Key: termbox.KeyEsc, Mod: termbox.ModAlt,
+	}))
+	// Runes have zero key which is KeyCtrlSpace
+	eb.AddExitKeys(termbox.KeyCtrlSpace)
+	assert.True(t, eb.isExit(key(termbox.KeyCtrlSpace)))
+	assert.False(t, eb.isExit(termbox.Event{Type: termbox.EventKey, Ch: 'b'}))
+	assert.False(t, eb.isExit(termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 5, MouseY: 2,
+	}))
+	assert.True(t, eb.isExit(termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 5, MouseY: 3,
+	}))
+	assert.False(t, eb.isExit(termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 5, MouseY: 3,
+		Mod: termbox.ModMotion,
+	}))
+}
+
+func TestSelectIsExit(t *testing.T) {
+	sbox := Select(0, 0, 10, 2, 0, 0, 0, 0, []string{"foo", "bar"})
+	for _, k := range []termbox.Key{
+		termbox.KeyEnter, termbox.KeyEsc, termbox.KeyTab,
+	} {
+		assert.True(t, sbox.isExit(termbox.Event{Type: termbox.EventKey, Key: k}))
+	}
+	assert.False(t, sbox.isExit(termbox.Event{
+		Type: termbox.EventKey, Key: termbox.KeyArrowDown,
+	}))
+	assert.False(t, sbox.isExit(termbox.Event{Type: termbox.EventKey, Ch: 'q'}))
+}
+
+func TestWaitExitAfterCancel(t *testing.T) {
+	useTestScreen(t, NewMemoryScreen(10, 1))
+	ch := make(chan termbox.Event)
+	SetEventSource(NewChanEvents(ch))
+	eb := Input(0, 0, 10, 0, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	_, err := eb.WaitExitContext(ctx)
+	assert.Equal(t, err, context.Canceled)
+
+	// Polling of cancelled call is stopped, so no event is lost
+	go func() {
+		for _, r := range "ab" {
+			ch <- termbox.Event{Type: termbox.EventKey, Ch: r}
+		}
+		ch <- termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter}
+	}()
+	ev, err := eb.WaitExit()
+	assert.Nil(t, err)
+	assert.Equal(t, ev.Key, termbox.KeyEnter)
+	assert.Equal(t, eb.Text(), "ab")
+}
+
+func TestFormRunContext(t *testing.T) {
+	useTestScreen(t, NewMemoryScreen(20, 3))
+	ch := make(chan termbox.Event)
+	SetEventSource(NewChanEvents(ch))
+	form := NewForm()
+	form.AddEditbox("name", Input(0, 0, 10, 0, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	submitted, _, err := form.RunContext(ctx)
+	assert.False(t, submitted)
+	assert.Equal(t, err, context.Canceled)
+
+	// Polling of cancelled call is stopped, so no event is lost
+	go func() {
+		for _, r := range "ab" {
+			ch <- termbox.Event{Type: termbox.EventKey, Ch: r}
+		}
+		ch <- termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter}
+	}()
+	submitted, ev, err := form.Run()
+	assert.Nil(t, err)
+	assert.True(t, submitted)
+	assert.Equal(t, ev.Key, termbox.KeyEnter)
+	assert.Equal(t, form.Values(), map[string]string{"name": "ab"})
+}
+
+func TestFormRunLeavesEvents(t *testing.T) {
+	s := NewMemoryScreen(20, 3)
+	s.Type("a").Press(termbox.KeyEnter).Type("b")
+	useTestScreen(t, s)
+	form := NewForm()
+	form.AddEditbox("name", Input(0, 0, 10, 0, 0))
+	submitted, _, err := form.Run()
+	assert.Nil(t, err)
+	assert.True(t, submitted)
+	assert.Equal(t, s.Len(), 1)
+}
+
+func TestStructFormRunContext(t *testing.T) {
+	useTestScreen(t, NewMemoryScreen(40, 10))
+	config := testConfig{Host: "localhost"}
+	sf, err := NewStructForm(&config, 0, 0)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	SetEventSource(NewChanEvents(make(chan termbox.Event)))
+	submitted, _, err := sf.RunContext(ctx, nil)
+	assert.False(t, submitted)
+	assert.Equal(t, err, context.DeadlineExceeded)
+}
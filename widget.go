@@ -0,0 +1,195 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"strings"
+)
+
+// Common interface of all widgets
+type Widget interface {
+	Sizer
+	// Puts widget into screen cell buffer.
+	// This DOES NOT flush screen.
+	Render()
+	// Processes termbox event. Returns false if event is not used
+	// by widget, like Enter in Input.
+	HandleEvent(ev termbox.Event) bool
+	// Returns true if widget can take focus
+	Focusable() bool
+	// Focused widget shows cursor or selection
+	Focus()
+	Blur()
+	// Returns widget value
+	Text() string
+}
+
+//----------------------------------------------------------------------------
+// Editbox
+//----------------------------------------------------------------------------
+
+func (ebox *Editbox) Focusable() bool {
+	return true
+}
+
+// Makes Render show cursor. Widgets are focused when created.
+func (ebox *Editbox) Focus() {
+	ebox.blurred = false
+}
+
+// Makes Render not touch terminal cursor.
+func (ebox *Editbox) Blur() {
+	ebox.blurred = true
+}
+
+//----------------------------------------------------------------------------
+// SelectBox
+//----------------------------------------------------------------------------
+
+func (sbox *SelectBox) Focusable() bool {
+	return true
+}
+
+// Makes Render highlight selected item with selection colors.
+// Widgets are focused when created.
+func (sbox *SelectBox) Focus() {
+	sbox.blurred = false
+}
+
+// Makes Render show selected item reversed in widget colors.
+func (sbox *SelectBox) Blur() {
+	sbox.blurred = true
+}
+
+//----------------------------------------------------------------------------
+// LabelBox
+//----------------------------------------------------------------------------
+
+// Retained Label
+type LabelBox struct {
+	x, y, width int
+	fg, bg      termbox.Attribute
+	text        string
+}
+
+// Create new Label widget. See Label.
+// This DOES NOT render widget.
+func NewLabel(x, y, width int, fg, bg termbox.Attribute, text string) *LabelBox {
+	return &LabelBox{x, y, width, fg, bg, text}
+}
+
+func (lbox *LabelBox) Render() {
+	Label(lbox.x, lbox.y, lbox.width, lbox.fg, lbox.bg, lbox.text)
+}
+
+func (lbox *LabelBox) HandleEvent(ev termbox.Event) bool {
+	return false
+}
+
+// Returns widget position and size. Zero width label is as wide
+// as its text.
+func (lbox *LabelBox) Bounds() (x, y, width, height int) {
+	width = lbox.width
+	if width <= 0 {
+		width = runeWidth(lbox.text)
+	}
+	return lbox.x, lbox.y, width, 1
+}
+
+func (lbox *LabelBox) SetPosition(x, y int) {
+	lbox.x = x
+	lbox.y = y
+}
+
+// Changes label width. Label is always one line high.
+func (lbox *LabelBox) SetSize(width, height int) {
+	lbox.width = width
+}
+
+func (lbox *LabelBox) Focusable() bool {
+	return false
+}
+
+func (lbox *LabelBox) Focus() {}
+
+func (lbox *LabelBox) Blur() {}
+
+func (lbox *LabelBox) Text() string {
+	return lbox.text
+}
+
+func (lbox *LabelBox) SetText(text string) {
+	lbox.text = text
+}
+
+//----------------------------------------------------------------------------
+// TextBox
+//----------------------------------------------------------------------------
+
+// Retained Text
+type TextBox struct {
+	x, y, width, height int
+	fg, bg              termbox.Attribute
+	text                string
+}
+
+// Create new Text widget. See Text.
+// This DOES NOT render widget.
+func NewText(
+	x, y, width, height int,
+	fg, bg termbox.Attribute,
+	text string,
+) *TextBox {
+	return &TextBox{x, y, width, height, fg, bg, text}
+}
+
+func (tbox *TextBox) Render() {
+	Text(tbox.x, tbox.y, tbox.width, tbox.height, tbox.fg, tbox.bg, tbox.text)
+}
+
+func (tbox *TextBox) HandleEvent(ev termbox.Event) bool {
+	return false
+}
+
+// Returns widget position and size. Zero width or height text is as
+// wide or high as its content.
+func (tbox *TextBox) Bounds() (x, y, width, height int) {
+	width, height = tbox.width, tbox.height
+	lines := strings.Split(strings.TrimSuffix(tbox.text, "\n"), "\n")
+	if width <= 0 {
+		for _, s := range lines {
+			if w := runeWidth(s); w > width {
+				width = w
+			}
+		}
+	}
+	if height <= 0 {
+		height = len(lines)
+	}
+	return tbox.x, tbox.y, width, height
+}
+
+func (tbox *TextBox) SetPosition(x, y int) {
+	tbox.x = x
+	tbox.y = y
+}
+
+func (tbox *TextBox) SetSize(width, height int) {
+	tbox.width = width
+	tbox.height = height
+}
+
+func (tbox *TextBox) Focusable() bool {
+	return false
+}
+
+func (tbox *TextBox) Focus() {}
+
+func (tbox *TextBox) Blur() {}
+
+func (tbox *TextBox) Text() string {
+	return tbox.text
+}
+
+func (tbox *TextBox) SetText(text string) {
+	tbox.text = text
+}
@@ -0,0 +1,79 @@
+package editbox
+
+import (
+	"github.com/nsf/termbox-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	_ Widget = (*Editbox)(nil)
+	_ Widget = (*SelectBox)(nil)
+	_ Widget = (*LabelBox)(nil)
+	_ Widget = (*TextBox)(nil)
+)
+
+func TestEditboxConsumed(t *testing.T) {
+	eb := newTestInput(0, 0, 10)
+	key := func(key termbox.Key) bool {
+		return eb.HandleEvent(termbox.Event{Type: termbox.EventKey, Key: key})
+	}
+	assert.True(t, eb.HandleEvent(termbox.Event{Type: termbox.EventKey, Ch: 'a'}))
+	assert.True(t, key(termbox.KeyArrowLeft))
+	assert.False(t, key(termbox.KeyEnter))
+	assert.False(t, key(termbox.KeyTab))
+	assert.False(t, key(termbox.KeyF1))
+	assert.False(t, eb.HandleEvent(termbox.Event{
+		Type: termbox.EventKey, Ch: 'z', Mod: termbox.ModAlt,
+	}))
+	assert.False(t, eb.HandleEvent(termbox.Event{
+		Type: termbox.EventMouse, Key: termbox.MouseLeft, MouseX: 20,
+	}))
+	assert.Equal(t, eb.Text(), "a")
+
+	ta := newTestTextarea(0, 0, 10, 3)
+	assert.True(t, ta.HandleEvent(termbox.Event{
+		Type: termbox.EventKey, Key: termbox.KeyEnter,
+	}))
+	assert.Equal(t, ta.Text(), "\n")
+}
+
+func TestLabelBox(t *testing.T) {
+	lbox := NewLabel(1, 2, 0, 0, 0, "Имя:")
+	x, y, width, height := lbox.Bounds()
+	assert.Equal(t, [4]int{x, y, width, height}, [4]int{1, 2, 4, 1})
+	lbox.SetSize(10, 3)
+	lbox.SetText("Name:")
+	_, _, width, height = lbox.Bounds()
+	assert.Equal(t, width, 10)
+	assert.Equal(t, height, 1)
+	assert.Equal(t, lbox.Text(), "Name:")
+	assert.False(t, lbox.Focusable())
+}
+
+func TestTextBox(t *testing.T) {
+	tbox := NewText(0, 0, 0, 0, 0, 0, "foo\nfoobar\n")
+	_, _, width, height := tbox.Bounds()
+	assert.Equal(t, width, 6)
+	assert.Equal(t, height, 2)
+	tbox.SetSize(3, 5)
+	_, _, width, height = tbox.Bounds()
+	assert.Equal(t, width, 3)
+	assert.Equal(t, height, 5)
+}
+
+func TestFormFocusBlur(t *testing.T) {
+	form := newTestForm()
+	name := form.items[1].(*Editbox)
+	notes := form.items[2].(*Editbox)
+	color := form.items[3].(*SelectBox)
+	assert.False(t, name.blurred)
+	assert.True(t, notes.blurred)
+	assert.True(t, color.blurred)
+	formKey(form, termbox.KeyTab)
+	assert.True(t, name.blurred)
+	assert.False(t, notes.blurred)
+	form.Focus("color")
+	assert.True(t, notes.blurred)
+	assert.False(t, color.blurred)
+}